@@ -2,12 +2,12 @@
 package amazonmws
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 const (
-	bulklimit = 18
 	prodAPI   = "/Products/2011-10-01"
 	reportAPI = "/Reports/2009-01-01"
 )
@@ -16,49 +16,82 @@ const (
 GetLowestOfferListingsForASIN takes a list of ASINs and returns the result.
 */
 func (api MWSAPI) GetLowestOfferListingsForASIN(items []string) (string, error) {
-	params := make(map[string]string)
-
-	for k, v := range items {
-		key := fmt.Sprintf("ASINList.ASIN.%d", (k + 1))
-		params[key] = string(v)
-	}
-
-	params["MarketplaceId"] = string(api.MarketplaceID)
+	return api.GetLowestOfferListingsForASINWithContext(context.Background(), items)
+}
 
-	return api.genSignAndFetch("GetLowestOfferListingsForASIN", prodAPI, params)
+// GetLowestOfferListingsForASINWithContext is GetLowestOfferListingsForASIN with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetLowestOfferListingsForASINWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetLowestOfferListingsForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
 }
 
 /*
 GetCompetitivePricingForASIN takes a list of ASINs and returns the result.
 */
 func (api MWSAPI) GetCompetitivePricingForASIN(items []string) (string, error) {
-	params := make(map[string]string)
+	return api.GetCompetitivePricingForASINWithContext(context.Background(), items)
+}
 
-	for k, v := range items {
-		key := fmt.Sprintf("ASINList.ASIN.%d", (k + 1))
-		params[key] = string(v)
-	}
+// GetCompetitivePricingForASINWithContext is GetCompetitivePricingForASIN with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetCompetitivePricingForASINWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetCompetitivePricingForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
+}
 
-	params["MarketplaceId"] = string(api.MarketplaceID)
+/*
+GetCompetitivePricingForSKU takes a list of SKUs and returns the result.
+*/
+func (api MWSAPI) GetCompetitivePricingForSKU(items []string) (string, error) {
+	return api.GetCompetitivePricingForSKUWithContext(context.Background(), items)
+}
 
-	return api.genSignAndFetch("GetCompetitivePricingForASIN", prodAPI, params)
+// GetCompetitivePricingForSKUWithContext is GetCompetitivePricingForSKU with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetCompetitivePricingForSKUWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetCompetitivePricingForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
 }
 
 // GetMatchingProductForID returns a list of products and their attributes,
 // based on a list of product identifier values that you specify.
 // Possible product identifiers are ASIN, GCID, SellerSKU, UPC, EAN, ISBN, and JAN.
 func (api MWSAPI) GetMatchingProductForID(idType string, idList []string) (string, error) {
-	params := make(map[string]string)
+	return api.GetMatchingProductForIDWithContext(context.Background(), idType, idList)
+}
 
-	for k, v := range idList {
-		key := fmt.Sprintf("IdList.Id.%d", (k + 1))
-		params[key] = string(v)
-	}
+// GetMatchingProductForIDWithContext is GetMatchingProductForID with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetMatchingProductForIDWithContext(ctx context.Context, idType string, idList []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetMatchingProductForId", prodAPI, api.matchingProductForIDParams(idType, idList))
+}
 
+func (api MWSAPI) matchingProductForIDParams(idType string, idList []string) map[string]string {
+	params := api.listParams("IdList.Id", idList)
 	params["IdType"] = idType
-	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
+}
+
+// ListMatchingProducts returns a list of at most 10 products and their
+// attributes, ordered by relevancy, for a search query.
+// queryContextID optionally narrows the search to a product category, e.g. Books;
+// pass "" to search all categories.
+func (api MWSAPI) ListMatchingProducts(query string, queryContextID string) (string, error) {
+	return api.ListMatchingProductsWithContext(context.Background(), query, queryContextID)
+}
+
+// ListMatchingProductsWithContext is ListMatchingProducts with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) ListMatchingProductsWithContext(ctx context.Context, query string, queryContextID string) (string, error) {
+	return api.genSignAndFetch(ctx, "ListMatchingProducts", prodAPI, api.listMatchingProductsParams(query, queryContextID))
+}
 
-	return api.genSignAndFetch("GetMatchingProductForId", prodAPI, params)
+func (api MWSAPI) listMatchingProductsParams(query string, queryContextID string) map[string]string {
+	params := make(map[string]string)
+	params["MarketplaceId"] = string(api.MarketplaceID)
+	params["Query"] = query
+	if queryContextID != "" {
+		params["QueryContextId"] = queryContextID
+	}
+	return params
 }
 
 // GetMyPriceForSKU returns pricing information for your own offer listings,
@@ -67,33 +100,49 @@ func (api MWSAPI) GetMatchingProductForID(idType string, idList []string) (strin
 // the operation returns an empty Offers element.
 // This operation returns pricing information for a maximum of 20 offer listings.
 func (api MWSAPI) GetMyPriceForSKU(items []string) (string, error) {
-	params := make(map[string]string)
+	return api.GetMyPriceForSKUWithContext(context.Background(), items)
+}
 
-	for k, v := range items {
-		key := fmt.Sprintf("SellerSKUList.SellerSKU.%d", (k + 1))
-		params[key] = string(v)
-	}
-	params["MarketplaceId"] = string(api.MarketplaceID)
+// GetMyPriceForSKUWithContext is GetMyPriceForSKU with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetMyPriceForSKUWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetMyPriceForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
+}
 
-	return api.genSignAndFetch("GetMyPriceForSKU", prodAPI, params)
+// GetMyPriceForASIN is GetMyPriceForSKU for a list of ASINs.
+func (api MWSAPI) GetMyPriceForASIN(items []string) (string, error) {
+	return api.GetMyPriceForASINWithContext(context.Background(), items)
+}
+
+// GetMyPriceForASINWithContext is GetMyPriceForASIN with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetMyPriceForASINWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetMyPriceForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
 }
 
 // GetLowestOfferListingsForSKU takes a list of SKUs and returns the result.
 func (api MWSAPI) GetLowestOfferListingsForSKU(items []string) (string, error) {
-	params := make(map[string]string)
-
-	for k, v := range items {
-		key := fmt.Sprintf("SellerSKUList.SellerSKU.%d", (k + 1))
-		params[key] = string(v)
-	}
-
-	params["MarketplaceId"] = string(api.MarketplaceID)
+	return api.GetLowestOfferListingsForSKUWithContext(context.Background(), items)
+}
 
-	return api.genSignAndFetch("GetLowestOfferListingsForSKU", prodAPI, params)
+// GetLowestOfferListingsForSKUWithContext is GetLowestOfferListingsForSKU with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetLowestOfferListingsForSKUWithContext(ctx context.Context, items []string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetLowestOfferListingsForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
 }
 
 // GetLowestPricedOffersForSKU takes a single SKU and returns the result.
 func (api MWSAPI) GetLowestPricedOffersForSKU(item string) (string, error) {
+	return api.GetLowestPricedOffersForSKUWithContext(context.Background(), item)
+}
+
+// GetLowestPricedOffersForSKUWithContext is GetLowestPricedOffersForSKU with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetLowestPricedOffersForSKUWithContext(ctx context.Context, item string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetLowestPricedOffersForSKU", prodAPI, api.lowestPricedOffersForSKUParams(item))
+}
+
+func (api MWSAPI) lowestPricedOffersForSKUParams(item string) map[string]string {
 	params := make(map[string]string)
 	// ItemCondition is a required field
 	// ItemCondition values: New, Used, Collectible, Refurbished, Club.
@@ -101,51 +150,198 @@ func (api MWSAPI) GetLowestPricedOffersForSKU(item string) (string, error) {
 	sku := fmt.Sprintf("SellerSKU")
 	params[sku] = item
 	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
+}
+
+// GetLowestPricedOffersForASIN takes a single ASIN and returns the result.
+func (api MWSAPI) GetLowestPricedOffersForASIN(item string) (string, error) {
+	return api.GetLowestPricedOffersForASINWithContext(context.Background(), item)
+}
 
-	return api.genSignAndFetch("GetLowestPricedOffersForSKU", prodAPI, params)
+// GetLowestPricedOffersForASINWithContext is GetLowestPricedOffersForASIN with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetLowestPricedOffersForASINWithContext(ctx context.Context, item string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetLowestPricedOffersForASIN", prodAPI, api.lowestPricedOffersForASINParams(item))
+}
+
+func (api MWSAPI) lowestPricedOffersForASINParams(item string) map[string]string {
+	params := make(map[string]string)
+	// ItemCondition is a required field
+	// ItemCondition values: New, Used, Collectible, Refurbished, Club.
+	params["ItemCondition"] = "New"
+	params["ASIN"] = item
+	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
 }
 
 // GetProductCategoriesForSKU takes a single SKU and returns the result.
 func (api MWSAPI) GetProductCategoriesForSKU(item string) (string, error) {
+	return api.GetProductCategoriesForSKUWithContext(context.Background(), item)
+}
+
+// GetProductCategoriesForSKUWithContext is GetProductCategoriesForSKU with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetProductCategoriesForSKUWithContext(ctx context.Context, item string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetProductCategoriesForSKU", prodAPI, api.productCategoriesForSKUParams(item))
+}
+
+func (api MWSAPI) productCategoriesForSKUParams(item string) map[string]string {
 	params := make(map[string]string)
 	sku := fmt.Sprintf("SellerSKU")
 	params[sku] = item
 	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
+}
+
+// GetProductCategoriesForASIN takes a single ASIN and returns the result.
+func (api MWSAPI) GetProductCategoriesForASIN(item string) (string, error) {
+	return api.GetProductCategoriesForASINWithContext(context.Background(), item)
+}
+
+// GetProductCategoriesForASINWithContext is GetProductCategoriesForASIN with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetProductCategoriesForASINWithContext(ctx context.Context, item string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetProductCategoriesForASIN", prodAPI, api.productCategoriesForASINParams(item))
+}
+
+func (api MWSAPI) productCategoriesForASINParams(item string) map[string]string {
+	params := make(map[string]string)
+	params["ASIN"] = item
+	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
+}
+
+// GetMyFeesEstimate returns the estimated fees for up to
+// MaxFeesEstimateRequests products.
+func (api MWSAPI) GetMyFeesEstimate(requests []FeesEstimateRequest) (string, error) {
+	return api.GetMyFeesEstimateWithContext(context.Background(), requests)
+}
 
-	return api.genSignAndFetch("GetProductCategoriesForSKU", prodAPI, params)
+// GetMyFeesEstimateWithContext is GetMyFeesEstimate with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetMyFeesEstimateWithContext(ctx context.Context, requests []FeesEstimateRequest) (string, error) {
+	params, err := api.feesEstimateParams(requests)
+	if err != nil {
+		return "", err
+	}
+	return api.genSignAndFetch(ctx, "GetMyFeesEstimate", prodAPI, params)
 }
 
 // RequestReport allows for requesting a Report from reportAPI
 func (api MWSAPI) RequestReport(report string, dateparams []string) (string, error) {
+	return api.RequestReportWithContext(context.Background(), report, dateparams)
+}
+
+// RequestReportWithContext is RequestReport with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) RequestReportWithContext(ctx context.Context, report string, dateparams []string) (string, error) {
+	params, err := api.requestReportParams(report, dateparams)
+	if err != nil {
+		return "", err
+	}
+	return api.genSignAndFetch(ctx, "RequestReport", reportAPI, params)
+}
+
+func (api MWSAPI) requestReportParams(report string, dateparams []string) (map[string]string, error) {
 	params := make(map[string]string)
 	l := len(dateparams)
 	if l > 2 {
-		return "", fmt.Errorf("Too many arguments. dateparams cannot exceed 2, a start and an end date")
+		return nil, fmt.Errorf("Too many arguments. dateparams cannot exceed 2, a start and an end date")
 	}
 	report = strings.ToUpper(report)
 	params["ReportType"] = report
+	// Both dates are optional: dateparams may be empty, a start date, or a
+	// start and an end date; pass "" as the start to give only an end.
 	// Expected format time.Now().Format("2006-01-02T15:04:05-07")
-	params["EndDate"] = dateparams[1]
-	params["StartDate"] = dateparams[0]
+	if l > 0 && dateparams[0] != "" {
+		params["StartDate"] = dateparams[0]
+	}
+	if l > 1 && dateparams[1] != "" {
+		params["EndDate"] = dateparams[1]
+	}
 
 	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params, nil
+}
+
+// RequestReportForMarketplaces requests a Report covering several marketplaces
+// of the seller's region, sent as MarketplaceIdList instead of MarketplaceId.
+func (api MWSAPI) RequestReportForMarketplaces(report string, dateparams []string, marketplaceIDs []string) (string, error) {
+	return api.RequestReportForMarketplacesWithContext(context.Background(), report, dateparams, marketplaceIDs)
+}
+
+// RequestReportForMarketplacesWithContext is RequestReportForMarketplaces with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) RequestReportForMarketplacesWithContext(ctx context.Context, report string, dateparams []string, marketplaceIDs []string) (string, error) {
+	params, err := api.requestReportForMarketplacesParams(report, dateparams, marketplaceIDs)
+	if err != nil {
+		return "", err
+	}
+	return api.genSignAndFetch(ctx, "RequestReport", reportAPI, params)
+}
 
-	return api.genSignAndFetch("RequestReport", reportAPI, params)
+func (api MWSAPI) requestReportForMarketplacesParams(report string, dateparams []string, marketplaceIDs []string) (map[string]string, error) {
+	if len(marketplaceIDs) == 0 {
+		return nil, fmt.Errorf("amazonmws: RequestReportForMarketplaces needs at least one MarketplaceId")
+	}
+	params, err := api.requestReportParams(report, dateparams)
+	if err != nil {
+		return nil, err
+	}
+	delete(params, "MarketplaceId")
+	for k, v := range marketplaceIDs {
+		params[fmt.Sprintf("MarketplaceIdList.Id.%d", (k+1))] = v
+	}
+	return params, nil
 }
 
 // GetReportRequestList Returns a list of report requests that you can use to get the ReportRequestId for a report.
 // ReportRequestIdList A structured list of ReportRequestId values. If you pass in ReportRequestId values, other query conditions are ignored.
 func (api MWSAPI) GetReportRequestList(params map[string]string) (string, error) {
-	// params := make(map[string]string)
-	params["MarketplaceId"] = string(api.MarketplaceID)
-	return api.genSignAndFetch("GetReportRequestList", reportAPI, params)
+	return api.GetReportRequestListWithContext(context.Background(), params)
+}
+
+// GetReportRequestListWithContext is GetReportRequestList with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetReportRequestListWithContext(ctx context.Context, params map[string]string) (string, error) {
+	return api.genSignAndFetch(ctx, "GetReportRequestList", reportAPI, api.reportRequestListParams(params))
+}
+
+// reportRequestListParams copies params, which may be nil, and adds the MarketplaceId.
+func (api MWSAPI) reportRequestListParams(params map[string]string) map[string]string {
+	p := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		p[k] = v
+	}
+	p["MarketplaceId"] = string(api.MarketplaceID)
+	return p
 }
 
 // GetReport Returns a list of report requests that you can use to get the ReportRequestId for a report.
 func (api MWSAPI) GetReport(id string) error {
+	return api.GetReportWithContext(context.Background(), id)
+}
+
+// GetReportWithContext is GetReport with a ctx that can cancel
+// the request or bound how long it may take.
+func (api MWSAPI) GetReportWithContext(ctx context.Context, id string) error {
 	params := make(map[string]string)
 	params["RepordId"] = id
 	params["MarketplaceId"] = string(api.MarketplaceID)
 	id = id + ".txt"
-	return api.genSignAndGet("GetReport", reportAPI, params, id)
+	return api.genSignAndGet(ctx, "GetReport", reportAPI, params, id)
+}
+
+// listParams numbers items from 1 under prefix, e.g. ASINList.ASIN.1,
+// and adds the MarketplaceId.
+func (api MWSAPI) listParams(prefix string, items []string) map[string]string {
+	params := make(map[string]string)
+
+	for k, v := range items {
+		key := fmt.Sprintf("%s.%d", prefix, (k + 1))
+		params[key] = string(v)
+	}
+
+	params["MarketplaceId"] = string(api.MarketplaceID)
+	return params
 }
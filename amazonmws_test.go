@@ -0,0 +1,65 @@
+package amazonmws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequestReportParamsDates(t *testing.T) {
+	api := MWSAPI{MarketplaceID: "ATVPDKIKX0DER"}
+	tests := []struct {
+		dates []string
+		want  map[string]string
+	}{
+		{nil, map[string]string{}},
+		{[]string{"2017-01-01T00:00:00-07"}, map[string]string{"StartDate": "2017-01-01T00:00:00-07"}},
+		{[]string{"", "2017-02-01T00:00:00-07"}, map[string]string{"EndDate": "2017-02-01T00:00:00-07"}},
+		{[]string{"2017-01-01T00:00:00-07", "2017-02-01T00:00:00-07"}, map[string]string{
+			"StartDate": "2017-01-01T00:00:00-07", "EndDate": "2017-02-01T00:00:00-07"}},
+	}
+	for _, tt := range tests {
+		got, err := api.requestReportParams("_get_merchant_listings_data_", tt.dates)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.dates, err)
+		}
+		tt.want["ReportType"] = "_GET_MERCHANT_LISTINGS_DATA_"
+		tt.want["MarketplaceId"] = "ATVPDKIKX0DER"
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: params = %v, want %v", tt.dates, got, tt.want)
+		}
+	}
+	if _, err := api.requestReportParams("R", []string{"a", "b", "c"}); err == nil {
+		t.Error("three dates: want an error")
+	}
+}
+
+func TestReportRequestListParamsCopies(t *testing.T) {
+	api := MWSAPI{MarketplaceID: "ATVPDKIKX0DER"}
+	if got := api.reportRequestListParams(nil); got["MarketplaceId"] != "ATVPDKIKX0DER" {
+		t.Fatalf("nil params = %v", got)
+	}
+	params := map[string]string{"ReportRequestIdList.Id.1": "42"}
+	got := api.reportRequestListParams(params)
+	if len(params) != 1 || got["ReportRequestIdList.Id.1"] != "42" || got["MarketplaceId"] != "ATVPDKIKX0DER" {
+		t.Fatalf("params = %v, caller's map = %v", got, params)
+	}
+}
+
+func TestRequestReportForMarketplacesParams(t *testing.T) {
+	api := MWSAPI{MarketplaceID: "ATVPDKIKX0DER"}
+	got, err := api.requestReportForMarketplacesParams("_GET_FLAT_FILE_OPEN_LISTINGS_DATA_", nil, []string{"A1F83G8C2ARO7P", "A1PA6795UKMFR9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"ReportType":             "_GET_FLAT_FILE_OPEN_LISTINGS_DATA_",
+		"MarketplaceIdList.Id.1": "A1F83G8C2ARO7P",
+		"MarketplaceIdList.Id.2": "A1PA6795UKMFR9",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("params = %v, want %v", got, want)
+	}
+	if _, err := api.requestReportForMarketplacesParams("R", nil, nil); err == nil {
+		t.Error("no marketplaces: want an error")
+	}
+}
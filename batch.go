@@ -0,0 +1,240 @@
+package amazonmws
+
+import (
+	"context"
+	"errors"
+
+	cats "github.com/rdorrigan/mws/parsers/cats"
+	"github.com/rdorrigan/mws/parsers/cp"
+	lowoff "github.com/rdorrigan/mws/parsers/lowoff"
+	"github.com/rdorrigan/mws/parsers/lowp"
+	"github.com/rdorrigan/mws/parsers/mp"
+	"github.com/rdorrigan/mws/parsers/mpfid"
+)
+
+// MaxBatchSize is the largest identifier list each operation accepts.
+var MaxBatchSize = map[string]int{
+	"GetLowestOfferListingsForASIN": 20,
+	"GetLowestOfferListingsForSKU":  20,
+	"GetCompetitivePricingForASIN":  20,
+	"GetCompetitivePricingForSKU":   20,
+	"GetMyPriceForSKU":              20,
+	"GetMyPriceForASIN":             20,
+	"GetMatchingProductForId":       5,
+	"GetLowestPricedOffersForSKU":   1,
+	"GetLowestPricedOffersForASIN":  1,
+	"GetProductCategoriesForSKU":    1,
+	"GetProductCategoriesForASIN":   1,
+}
+
+// The Batch operations accept any number of identifiers, send them in
+// chunks of MaxBatchSize through the rate limiter and merge the parsed
+// results keyed by identifier. A failed chunk does not stop the others;
+// its error is joined into the returned error.
+
+// GetLowestOfferListingsForASINBatch is GetLowestOfferListingsForASINParsed for any number of ASINs.
+func (api MWSAPI) GetLowestOfferListingsForASINBatch(ctx context.Context, asins []string) (map[string]Result, error) {
+	results := make(map[string]Result)
+	err := batch(ctx, "GetLowestOfferListingsForASIN", asins, func(items []string) error {
+		doc, err := api.GetLowestOfferListingsForASINParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range doc.Results {
+			results[r.ASIN] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetMyPriceForSKUBatch is GetMyPriceForSKUParsed for any number of SKUs.
+func (api MWSAPI) GetMyPriceForSKUBatch(ctx context.Context, skus []string) (map[string]mp.XMLResult, error) {
+	results := make(map[string]mp.XMLResult)
+	err := batch(ctx, "GetMyPriceForSKU", skus, func(items []string) error {
+		resp, err := api.GetMyPriceForSKUParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.SellerSKU] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetMyPriceForASINBatch is GetMyPriceForASINParsed for any number of ASINs.
+func (api MWSAPI) GetMyPriceForASINBatch(ctx context.Context, asins []string) (map[string]mp.XMLResult, error) {
+	results := make(map[string]mp.XMLResult)
+	err := batch(ctx, "GetMyPriceForASIN", asins, func(items []string) error {
+		resp, err := api.GetMyPriceForASINParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.ASIN] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetCompetitivePricingForASINBatch is GetCompetitivePricingForASINParsed for any number of ASINs.
+func (api MWSAPI) GetCompetitivePricingForASINBatch(ctx context.Context, asins []string) (map[string]cp.XMLResult, error) {
+	results := make(map[string]cp.XMLResult)
+	err := batch(ctx, "GetCompetitivePricingForASIN", asins, func(items []string) error {
+		resp, err := api.GetCompetitivePricingForASINParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.ASIN] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetCompetitivePricingForSKUBatch is GetCompetitivePricingForSKUParsed for any number of SKUs.
+func (api MWSAPI) GetCompetitivePricingForSKUBatch(ctx context.Context, skus []string) (map[string]cp.XMLResult, error) {
+	results := make(map[string]cp.XMLResult)
+	err := batch(ctx, "GetCompetitivePricingForSKU", skus, func(items []string) error {
+		resp, err := api.GetCompetitivePricingForSKUParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.SellerSKU] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetLowestOfferListingsForSKUBatch is GetLowestOfferListingsForSKUParsed for any number of SKUs.
+func (api MWSAPI) GetLowestOfferListingsForSKUBatch(ctx context.Context, skus []string) (map[string]lowoff.XMLResult, error) {
+	results := make(map[string]lowoff.XMLResult)
+	err := batch(ctx, "GetLowestOfferListingsForSKU", skus, func(items []string) error {
+		resp, err := api.GetLowestOfferListingsForSKUParsed(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.SellerSKU] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetMatchingProductForIDBatch is GetMatchingProductForIDParsed for any number of identifiers.
+func (api MWSAPI) GetMatchingProductForIDBatch(ctx context.Context, idType string, ids []string) (map[string]mpfid.XMLResult, error) {
+	results := make(map[string]mpfid.XMLResult)
+	err := batch(ctx, "GetMatchingProductForId", ids, func(items []string) error {
+		resp, err := api.GetMatchingProductForIDParsed(ctx, idType, items)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[r.ID] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetLowestPricedOffersForSKUBatch calls GetLowestPricedOffersForSKUParsed once per SKU.
+func (api MWSAPI) GetLowestPricedOffersForSKUBatch(ctx context.Context, skus []string) (map[string]lowp.XMLResult, error) {
+	results := make(map[string]lowp.XMLResult)
+	err := batch(ctx, "GetLowestPricedOffersForSKU", skus, func(items []string) error {
+		resp, err := api.GetLowestPricedOffersForSKUParsed(ctx, items[0])
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[items[0]] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetLowestPricedOffersForASINBatch calls GetLowestPricedOffersForASINParsed once per ASIN.
+func (api MWSAPI) GetLowestPricedOffersForASINBatch(ctx context.Context, asins []string) (map[string]lowp.XMLResult, error) {
+	results := make(map[string]lowp.XMLResult)
+	err := batch(ctx, "GetLowestPricedOffersForASIN", asins, func(items []string) error {
+		resp, err := api.GetLowestPricedOffersForASINParsed(ctx, items[0])
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			results[items[0]] = r
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetProductCategoriesForSKUBatch calls GetProductCategoriesForSKUParsed once per SKU.
+func (api MWSAPI) GetProductCategoriesForSKUBatch(ctx context.Context, skus []string) (map[string]cats.XMLResult, error) {
+	results := make(map[string]cats.XMLResult)
+	err := batch(ctx, "GetProductCategoriesForSKU", skus, func(items []string) error {
+		resp, err := api.GetProductCategoriesForSKUParsed(ctx, items[0])
+		if err != nil {
+			return err
+		}
+		results[items[0]] = resp.Results
+		return nil
+	})
+	return results, err
+}
+
+// GetProductCategoriesForASINBatch calls GetProductCategoriesForASINParsed once per ASIN.
+func (api MWSAPI) GetProductCategoriesForASINBatch(ctx context.Context, asins []string) (map[string]cats.XMLResult, error) {
+	results := make(map[string]cats.XMLResult)
+	err := batch(ctx, "GetProductCategoriesForASIN", asins, func(items []string) error {
+		resp, err := api.GetProductCategoriesForASINParsed(ctx, items[0])
+		if err != nil {
+			return err
+		}
+		results[items[0]] = resp.Results
+		return nil
+	})
+	return results, err
+}
+
+// batch calls fn with successive chunks of items sized for operation.
+// It stops early only when ctx is done.
+func batch(ctx context.Context, operation string, items []string, fn func(items []string) error) error {
+	var errs []error
+	for _, c := range chunk(items, batchSize(operation)) {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := fn(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func batchSize(operation string) int {
+	if n, ok := MaxBatchSize[operation]; ok {
+		return n
+	}
+	return 1
+}
+
+// chunk splits items into consecutive slices of at most size entries.
+func chunk(items []string, size int) [][]string {
+	var chunks [][]string
+	for size < len(items) {
+		items, chunks = items[size:], append(chunks, items[0:size:size])
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+	return chunks
+}
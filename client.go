@@ -0,0 +1,124 @@
+package amazonmws
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DefaultUserAgent is sent with every request unless WithUserAgent overrides it.
+// MWS asks for the form AppName/AppVersion (Language=LanguageName).
+const DefaultUserAgent = "amazonmws/1.0 (Language=Go)"
+
+// Option configures an MWSAPI built by NewMWSAPI.
+type Option func(*MWSAPI) error
+
+// NewMWSAPI returns a copy of config, which supplies the credentials,
+// Host and MarketplaceID, with opts applied in order. The client gets
+// its own RateLimiter built from Quotas unless WithRateLimiter says otherwise.
+//
+// A client used as an MWSAPI literal, without NewMWSAPI, is still rate
+// limited and retried with DefaultRetryPolicy: it shares a RateLimiter with
+// every other literal client of the same SellerID.
+func NewMWSAPI(config MWSAPI, opts ...Option) (*MWSAPI, error) {
+	api := config
+	limiter := NewRateLimiter(nil)
+	api.limiter = limiter
+	for _, opt := range opts {
+		if err := opt(&api); err != nil {
+			return nil, err
+		}
+	}
+	if api.limiter == limiter && api.clock != nil {
+		limiter.clock = api.clock
+	}
+	return &api, nil
+}
+
+// WithHTTPClient sends every request through c instead of http.DefaultClient,
+// which is where timeouts, proxies, pooling and TLS roots are configured.
+func WithHTTPClient(c *http.Client) Option {
+	return func(api *MWSAPI) error {
+		if c == nil {
+			return errors.New("amazonmws: nil http.Client")
+		}
+		api.client = c
+		return nil
+	}
+}
+
+// WithBaseURL points the client at rawurl, e.g. "http://127.0.0.1:8080" for a
+// local fake server. Its scheme and host replace the https scheme and Host.
+func WithBaseURL(rawurl string) Option {
+	return func(api *MWSAPI) error {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("amazonmws: base URL %q needs a scheme and host", rawurl)
+		}
+		api.scheme = u.Scheme
+		api.Host = u.Host
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(api *MWSAPI) error {
+		api.userAgent = ua
+		return nil
+	}
+}
+
+// WithClock sets the Clock used to timestamp requests and to wait between
+// retries. The client's default RateLimiter uses it too; build a limiter
+// for WithRateLimiter with NewRateLimiterWithClock to share the Clock.
+func WithClock(c Clock) Option {
+	return func(api *MWSAPI) error {
+		if c == nil {
+			return errors.New("amazonmws: nil Clock")
+		}
+		api.clock = c
+		return nil
+	}
+}
+
+// WithPOST sends the given operations as signed form POSTs instead of
+// signed GETs, for requests whose parameters are too long for a URL.
+func WithPOST(operations ...string) Option {
+	return func(api *MWSAPI) error {
+		ops := make(map[string]bool, len(api.postOps)+len(operations))
+		for op := range api.postOps {
+			ops[op] = true
+		}
+		for _, op := range operations {
+			ops[op] = true
+		}
+		api.postOps = ops
+		return nil
+	}
+}
+
+func (api MWSAPI) method(operation string) string {
+	if api.postOps[operation] {
+		return http.MethodPost
+	}
+	return http.MethodGet
+}
+
+func (api MWSAPI) httpClient() *http.Client {
+	if api.client == nil {
+		return http.DefaultClient
+	}
+	return api.client
+}
+
+func (api MWSAPI) userAgentHeader() string {
+	if api.userAgent == "" {
+		return DefaultUserAgent
+	}
+	return api.userAgent
+}
@@ -0,0 +1,110 @@
+package amazonmws
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the client what time it is and provides the timers behind
+// every wait in the package, so signing and throttling can be driven by
+// a fake clock in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the part of *time.Timer a Clock must provide.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Ticker is the part of *time.Ticker a Clock must provide.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+func (systemClock) NewTicker(d time.Duration) Ticker { return systemTicker{time.NewTicker(d)} }
+
+type systemTimer struct{ t *time.Timer }
+
+func (s systemTimer) C() <-chan time.Time { return s.t.C }
+func (s systemTimer) Stop() bool          { return s.t.Stop() }
+
+type systemTicker struct{ t *time.Ticker }
+
+func (s systemTicker) C() <-chan time.Time { return s.t.C }
+func (s systemTicker) Stop()               { s.t.Stop() }
+
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return systemClock{}
+	}
+	return c
+}
+
+// now is the time used to sign requests: the client's Clock, corrected
+// for skew when WithClockSkewCorrection is on.
+func (api MWSAPI) now() time.Time {
+	now := clockOrSystem(api.clock).Now()
+	if api.skew != nil {
+		now = now.Add(api.skew.get())
+	}
+	return now
+}
+
+// WithClockSkewCorrection makes the client learn the offset between its
+// Clock and Amazon's from the Date header of a response rejecting a request
+// as expired. The request is then retried at once, and later Timestamps
+// are corrected by the same offset.
+func WithClockSkewCorrection() Option {
+	return func(api *MWSAPI) error {
+		api.skew = &clockSkew{}
+		return nil
+	}
+}
+
+// clockSkew is how far the server's clock is ahead of the local one.
+type clockSkew struct {
+	offset int64 /*time.Duration, accessed atomically*/
+}
+
+func (s *clockSkew) get() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.offset))
+}
+
+// correct records the offset if err rejected a request as expired and
+// carries the server's time. It reports whether a retry may now succeed.
+func (s *clockSkew) correct(err error, local time.Time) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Date.IsZero() || !expired(apiErr) {
+		return false
+	}
+	atomic.StoreInt64(&s.offset, int64(apiErr.Date.Sub(local)))
+	return true
+}
+
+func expired(e *APIError) bool {
+	return e.Code == RequestExpired ||
+		e.Code == Parameter && strings.Contains(strings.ToLower(e.Message), "expired")
+}
+
+// serverDate parses the Date header of a response, or returns the zero Time.
+func serverDate(h http.Header) time.Time {
+	t, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,275 @@
+package amazonmws
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials sign requests. SellerID and AuthToken are optional and only
+// fill in a client that has no SellerID of its own, so a provider never
+// changes which seller a client acts for; see MWSAPI.apply.
+type Credentials struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	SellerID  string `json:"seller_id"`
+	AuthToken string `json:"auth_token"`
+}
+
+// CredentialsProvider supplies Credentials. A client with a provider asks it
+// before signing every request, so rotated credentials are picked up without
+// rebuilding the client.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// WithCredentialsProvider signs requests with the Credentials from p
+// instead of the AccessKey and SecretKey fields.
+func WithCredentialsProvider(p CredentialsProvider) Option {
+	return func(api *MWSAPI) error {
+		if p == nil {
+			return errors.New("amazonmws: nil CredentialsProvider")
+		}
+		api.credentials = p
+		return nil
+	}
+}
+
+// Retrieve returns c, so fixed Credentials can be used as a provider.
+func (c Credentials) Retrieve(ctx context.Context) (Credentials, error) {
+	return c, c.validate()
+}
+
+func (c Credentials) validate() error {
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return errors.New("amazonmws: credentials need an access key and a secret key")
+	}
+	return nil
+}
+
+// apply returns a copy of api signing with c. The client's SellerID and
+// AuthToken win: c's are taken only when the client has no SellerID, and
+// c's AuthToken alone only when c names the client's own seller. Clients
+// of a SellerPool therefore keep their delegated seller even when the
+// provider, e.g. EnvProvider with MWS_SELLER_ID set, names another one.
+func (api MWSAPI) apply(c Credentials) MWSAPI {
+	api.AccessKey = c.AccessKey
+	api.SecretKey = c.SecretKey
+	switch {
+	case api.SellerID == "":
+		api.SellerID = c.SellerID
+		if api.AuthToken == "" {
+			api.AuthToken = c.AuthToken
+		}
+	case api.AuthToken == "" && c.SellerID == api.SellerID:
+		api.AuthToken = c.AuthToken
+	}
+	return api
+}
+
+// Environment variables read by EnvProvider.
+const (
+	EnvAccessKey = "MWS_ACCESS_KEY_ID"
+	EnvSecretKey = "MWS_SECRET_ACCESS_KEY"
+	EnvSellerID  = "MWS_SELLER_ID"
+	EnvAuthToken = "MWS_AUTH_TOKEN"
+)
+
+// EnvProvider reads Credentials from the MWS_* environment variables.
+type EnvProvider struct{}
+
+// Retrieve implements CredentialsProvider.
+func (EnvProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	c := Credentials{
+		AccessKey: os.Getenv(EnvAccessKey),
+		SecretKey: os.Getenv(EnvSecretKey),
+		SellerID:  os.Getenv(EnvSellerID),
+		AuthToken: os.Getenv(EnvAuthToken),
+	}
+	if err := c.validate(); err != nil {
+		return c, fmt.Errorf("%v: set %s and %s", err, EnvAccessKey, EnvSecretKey)
+	}
+	return c, nil
+}
+
+// FileProvider reads a named profile from a credentials file. The format
+// follows the extension: .json, .yaml or .yml, and INI for anything else.
+// Every format uses the keys access_key, secret_key, seller_id and auth_token:
+//
+//	[default]                 default:                  {"default": {
+//	access_key = AKIA...        access_key: AKIA...       "access_key": "AKIA...",
+//	secret_key = ...            secret_key: ...           "secret_key": "..."}}
+//
+// The file is read again whenever its modification time changes.
+type FileProvider struct {
+	Path    string
+	Profile string /*"default" when empty*/
+
+	mu      sync.Mutex
+	modTime time.Time
+	creds   Credentials
+}
+
+// Retrieve implements CredentialsProvider.
+func (p *FileProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if !info.ModTime().Equal(p.modTime) {
+		c, err := p.load()
+		if err != nil {
+			return Credentials{}, err
+		}
+		p.creds, p.modTime = c, info.ModTime()
+	}
+	return p.creds, nil
+}
+
+func (p *FileProvider) load() (Credentials, error) {
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	profile := p.Profile
+	if profile == "" {
+		profile = "default"
+	}
+
+	var profiles map[string]Credentials
+	switch strings.ToLower(filepath.Ext(p.Path)) {
+	case ".json":
+		err = json.Unmarshal(b, &profiles)
+	case ".yaml", ".yml":
+		profiles, err = parseProfiles(b, ":", isYAMLProfile)
+	default:
+		profiles, err = parseProfiles(b, "=", isINIProfile)
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("amazonmws: %s: %v", p.Path, err)
+	}
+
+	c, ok := profiles[profile]
+	if !ok {
+		return c, fmt.Errorf("amazonmws: %s has no profile %q", p.Path, profile)
+	}
+	if err := c.validate(); err != nil {
+		return c, fmt.Errorf("%v: profile %q in %s", err, profile, p.Path)
+	}
+	return c, nil
+}
+
+// isINIProfile recognizes a "[name]" section header.
+func isINIProfile(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+		return strings.TrimSpace(line[1 : len(line)-1]), true
+	}
+	return "", false
+}
+
+// isYAMLProfile recognizes an unindented "name:" mapping key.
+func isYAMLProfile(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return "", false
+	}
+	line = strings.TrimSpace(line)
+	if strings.HasSuffix(line, ":") {
+		return strings.TrimSpace(strings.TrimSuffix(line, ":")), true
+	}
+	return "", false
+}
+
+// parseProfiles reads the flat profile layout shared by INI and the subset
+// of YAML accepted here: a profile header followed by key sep value lines.
+func parseProfiles(b []byte, sep string, header func(string) (string, bool)) (map[string]Credentials, error) {
+	profiles := make(map[string]Credentials)
+	profile := ""
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") || trimmed == "---" {
+			continue
+		}
+		if name, ok := header(line); ok {
+			profile = name
+			continue
+		}
+		kv := strings.SplitN(trimmed, sep, 2)
+		if len(kv) != 2 || profile == "" {
+			return nil, fmt.Errorf("line %d: expected key %s value inside a profile", n, sep)
+		}
+		key := strings.TrimSpace(kv[0])
+		value := strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+		c := profiles[profile]
+		switch key {
+		case "access_key":
+			c.AccessKey = value
+		case "secret_key":
+			c.SecretKey = value
+		case "seller_id":
+			c.SellerID = value
+		case "auth_token":
+			c.AuthToken = value
+		}
+		profiles[profile] = c
+	}
+	return profiles, scanner.Err()
+}
+
+// ChainProvider returns the Credentials of the first provider that succeeds.
+type ChainProvider []CredentialsProvider
+
+// Retrieve implements CredentialsProvider.
+func (chain ChainProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	var errs []error
+	for _, p := range chain {
+		c, err := p.Retrieve(ctx)
+		if err == nil {
+			return c, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return Credentials{}, errors.New("amazonmws: empty ChainProvider")
+	}
+	return Credentials{}, errors.Join(errs...)
+}
+
+// RotatingCredentials is a provider whose Credentials can be replaced at
+// any time with Set, for secrets pushed from a vault or similar.
+type RotatingCredentials struct {
+	mu    sync.RWMutex
+	creds Credentials
+}
+
+// NewRotatingCredentials returns a RotatingCredentials holding c.
+func NewRotatingCredentials(c Credentials) *RotatingCredentials {
+	return &RotatingCredentials{creds: c}
+}
+
+// Set replaces the Credentials used by subsequent requests.
+func (r *RotatingCredentials) Set(c Credentials) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.creds = c
+}
+
+// Retrieve implements CredentialsProvider.
+func (r *RotatingCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.creds, r.creds.validate()
+}
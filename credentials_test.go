@@ -0,0 +1,53 @@
+package amazonmws
+
+import (
+	"context"
+	"testing"
+)
+
+func TestApplyKeepsClientSeller(t *testing.T) {
+	env := Credentials{AccessKey: "ak", SecretKey: "sk", SellerID: "ENV", AuthToken: "env-token"}
+
+	tests := []struct {
+		name              string
+		client            MWSAPI
+		c                 Credentials
+		seller, authToken string
+	}{
+		{"empty client", MWSAPI{}, env, "ENV", "env-token"},
+		{"delegated seller", MWSAPI{SellerID: "S1", AuthToken: "t1"}, env, "S1", "t1"},
+		{"seller without token", MWSAPI{SellerID: "S1"}, env, "S1", ""},
+		{"token for own seller", MWSAPI{SellerID: "ENV"}, env, "ENV", "env-token"},
+		{"keys only", MWSAPI{SellerID: "S1", AuthToken: "t1"}, Credentials{AccessKey: "ak", SecretKey: "sk"}, "S1", "t1"},
+	}
+	for _, tt := range tests {
+		got := tt.client.apply(tt.c)
+		if got.AccessKey != "ak" || got.SecretKey != "sk" || got.SellerID != tt.seller || got.AuthToken != tt.authToken {
+			t.Errorf("%s: apply = %q/%q/%q/%q, want ak/sk/%q/%q", tt.name,
+				got.AccessKey, got.SecretKey, got.SellerID, got.AuthToken, tt.seller, tt.authToken)
+		}
+	}
+}
+
+func TestSellerPoolIgnoresProviderSeller(t *testing.T) {
+	t.Setenv(EnvAccessKey, "ak")
+	t.Setenv(EnvSecretKey, "sk")
+	t.Setenv(EnvSellerID, "ENV")
+	t.Setenv(EnvAuthToken, "env-token")
+
+	pool := NewSellerPool(MWSAPI{}, WithCredentialsProvider(EnvProvider{}))
+	if err := pool.Add("S1", "t1", "US"); err != nil {
+		t.Fatal(err)
+	}
+	api, err := pool.Seller("S1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := api.credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := api.apply(c); got.SellerID != "S1" || got.AuthToken != "t1" {
+		t.Fatalf("pool client signs as %q/%q, want S1/t1", got.SellerID, got.AuthToken)
+	}
+}
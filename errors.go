@@ -1,10 +1,15 @@
 package amazonmws
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 // ErrorResponse contains the returned xml errors
@@ -42,7 +47,7 @@ type XMLResponseErrors struct {
 // XMLRequestID holds the ID
 type XMLRequestID struct {
 	XMLName xml.Name `xml:"RequestID"`
-	ID      string   `xml:"RequestID"`
+	ID      string   `xml:",chardata"`
 }
 
 // XMLParser represents an XML parser.
@@ -70,6 +75,8 @@ const (
 	SignDoesNotMatch = "SignatureDoesNotMatch"
 	// InvalidAddress will terminate
 	InvalidAddress = "InvalidAddress"
+	// RequestExpired will terminate unless clock skew correction is on
+	RequestExpired = "RequestExpired"
 	// InternalError will be throttled
 	InternalError = "InternalError"
 	// QuotaExceeded will be throttled
@@ -101,9 +108,66 @@ func (er *ErrorResponse) NewError(i int) {
 
 }
 
-// ParseError parses an mws xml error response
+// APIError is returned by the fetch path when MWS answers with an
+// ErrorResponse envelope or a non-2xx HTTP status. Use errors.As to get it.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	Message    string
+	Detail     string
+	RequestID  string
+	Date       time.Time /*server time from the Date header, if sent*/
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("mws: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("mws: HTTP %d %s: %s (RequestID %s)", e.StatusCode, e.Code, e.Message, e.RequestID)
+}
+
+// checkResponse returns an *APIError when body is an ErrorResponse
+// envelope or the status of resp is not 2xx. Only the first Error
+// element is kept.
+func checkResponse(resp *http.Response, body []byte) error {
+	var x XMLErrorResponse
+	if err := xml.Unmarshal(body, &x); err == nil && len(x.Error) > 0 {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Type:       x.Error[0].Type,
+			Code:       x.Error[0].Code,
+			Message:    x.Error[0].Message,
+			Detail:     x.Error[0].Detail,
+			RequestID:  x.RequestID.ID,
+			Date:       serverDate(resp.Header),
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(body)),
+			Date:       serverDate(resp.Header),
+		}
+	}
+	return nil
+}
+
+// ParseError parses an mws xml error response.
+// An *APIError from the fetch path is converted directly.
 func (p *XMLParser) ParseError(e error) *ErrorResponse {
 	ER := ErrorResponse{}
+	var apiErr *APIError
+	if errors.As(e, &apiErr) {
+		ER.Response.Error = []XMLResponseErrors{{
+			Type:    apiErr.Type,
+			Code:    apiErr.Code,
+			Message: apiErr.Message,
+			Detail:  apiErr.Detail,
+		}}
+		ER.Response.RequestID.ID = apiErr.RequestID
+		return &ER
+	}
 	b := []byte(e.Error())
 	if err := xml.Unmarshal(b, &ER.Response); err != nil {
 		ER.Error = err
@@ -115,26 +179,37 @@ func (p *XMLParser) ParseError(e error) *ErrorResponse {
 // ResolveError finds a solution to errors that are due to throttling
 // and exits when the error is due to an invalid request
 func (er *ErrorResponse) ResolveError() {
+	er.ResolveErrorContext(context.Background())
+}
+
+// ResolveErrorContext is ResolveError but abandons any throttling wait
+// and returns ctx.Err() once ctx is done.
+func (er *ErrorResponse) ResolveErrorContext(ctx context.Context) error {
 	for k, e := range er.Response.Error {
 		er.CheckCode(k)
-		fmt.Println("Error: ", e.Code)
+		er.Throttle.log(ctx, slog.LevelWarn, "mws error", "code", e.Code, "message", e.Message)
 		if er.Throttle.Throttled == true {
-			er.Throttle.NewTicker()
+			if err := er.Throttle.NewTickerContext(ctx); err != nil {
+				return err
+			}
 		} else {
 			er.NewError(k)
 		}
 	}
+	return nil
+}
 
+// retryableCodes are the codes that resolve themselves after a wait.
+var retryableCodes = []string{
+	InternalError,
+	QuotaExceeded,
+	ReqThrottled,
+	ReportNotReady,
 }
 
 // CheckCode iterates through the const valid codes
 func (er *ErrorResponse) CheckCode(i int) {
-	validcodes := []string{
-		InternalError,
-		QuotaExceeded,
-		ReqThrottled,
-		ReportNotReady,
-	}
+	validcodes := retryableCodes
 	exitcodes := []string{
 		Disconnect,
 		Parameter,
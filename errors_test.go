@@ -0,0 +1,76 @@
+package amazonmws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCheckResponseEnvelope(t *testing.T) {
+	date := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`<?xml version="1.0"?>
+<ErrorResponse xmlns="https://mws.amazonservices.com/">
+  <Error><Type>Sender</Type><Code>AccessDenied</Code><Message>Access denied</Message><Detail>none</Detail></Error>
+  <RequestID>b5ce2d7b-5f2b-4b3d-9a07-1b4a4e4e3b7f</RequestID>
+</ErrorResponse>`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	body, err := api.GetMyPriceForSKU([]string{"sku"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	want := APIError{
+		StatusCode: http.StatusUnauthorized,
+		Type:       "Sender",
+		Code:       AccessDenied,
+		Message:    "Access denied",
+		Detail:     "none",
+		RequestID:  "b5ce2d7b-5f2b-4b3d-9a07-1b4a4e4e3b7f",
+		Date:       date,
+	}
+	if *apiErr != want {
+		t.Fatalf("APIError = %+v, want %+v", *apiErr, want)
+	}
+	if body == "" {
+		t.Fatal("error body not returned for inspection")
+	}
+
+	er := NewXMLParser().ParseError(err)
+	if er.Response.Error[0].Code != AccessDenied || er.Response.RequestID.ID != want.RequestID {
+		t.Fatalf("ParseError = %+v", er.Response)
+	}
+}
+
+func TestCheckResponseStatusWithoutEnvelope(t *testing.T) {
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("bad gateway\n"))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := api.GetMyPriceForSKU([]string{"sku"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Code != "" || apiErr.Message != "bad gateway" {
+		t.Fatalf("APIError = %+v", *apiErr)
+	}
+	if !Retryable(err) {
+		t.Fatal("5xx without an envelope is not retryable")
+	}
+}
+
+func TestCheckResponseSuccess(t *testing.T) {
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<GetMyPriceForSKUResponse/>`))
+	})
+	if _, err := api.GetMyPriceForSKUWithContext(context.Background(), []string{"sku"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,77 @@
+package amazonmws
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MaxFeesEstimateRequests is the most FeesEstimateRequests one
+// GetMyFeesEstimate call accepts.
+const MaxFeesEstimateRequests = 20
+
+// FeesEstimateRequest is one product to estimate the fees of.
+type FeesEstimateRequest struct {
+	IDType            string /*ASIN or SellerSKU*/
+	IDValue           string
+	IsAmazonFulfilled bool
+	// Identifier is echoed back as SellerInputIdentifier to match results
+	// to requests. IDValue is used when it is empty.
+	Identifier    string
+	ListingPrice  float64
+	ShippingPrice float64
+	Points        int /*Amazon points, JP only*/
+	// CurrencyCode defaults to the currency of the client's marketplace.
+	CurrencyCode string
+	// MarketplaceID defaults to the client's MarketplaceID.
+	MarketplaceID string
+}
+
+// feesEstimatePrefix numbers the requests of a FeesEstimateRequestList.
+const feesEstimatePrefix = "FeesEstimateRequestList.FeesEstimateRequest."
+
+// feesEstimateParams builds the FeesEstimateRequestList for requests.
+func (api MWSAPI) feesEstimateParams(requests []FeesEstimateRequest) (map[string]string, error) {
+	if len(requests) == 0 || len(requests) > MaxFeesEstimateRequests {
+		return nil, fmt.Errorf("amazonmws: GetMyFeesEstimate takes 1 to %d requests, got %d", MaxFeesEstimateRequests, len(requests))
+	}
+	params := make(map[string]string)
+	for k, r := range requests {
+		if r.IDType != "ASIN" && r.IDType != "SellerSKU" {
+			return nil, fmt.Errorf("amazonmws: fees estimate IdType must be ASIN or SellerSKU, got %q", r.IDType)
+		}
+		if r.IDValue == "" {
+			return nil, fmt.Errorf("amazonmws: fees estimate request %d has no IdValue", k+1)
+		}
+		marketplaceID := r.MarketplaceID
+		if marketplaceID == "" {
+			marketplaceID = api.MarketplaceID
+		}
+		currency := r.CurrencyCode
+		if currency == "" {
+			m, ok := MarketplaceByID(marketplaceID)
+			if !ok {
+				return nil, fmt.Errorf("amazonmws: no currency for marketplace %q, set CurrencyCode", marketplaceID)
+			}
+			currency = m.Currency
+		}
+		identifier := r.Identifier
+		if identifier == "" {
+			identifier = r.IDValue
+		}
+
+		p := fmt.Sprintf("%s%d.", feesEstimatePrefix, k+1)
+		params[p+"MarketplaceId"] = marketplaceID
+		params[p+"IdType"] = r.IDType
+		params[p+"IdValue"] = r.IDValue
+		params[p+"IsAmazonFulfilled"] = strconv.FormatBool(r.IsAmazonFulfilled)
+		params[p+"Identifier"] = identifier
+		params[p+"PriceToEstimateFees.ListingPrice.Amount"] = strconv.FormatFloat(r.ListingPrice, 'f', 2, 64)
+		params[p+"PriceToEstimateFees.ListingPrice.CurrencyCode"] = currency
+		params[p+"PriceToEstimateFees.Shipping.Amount"] = strconv.FormatFloat(r.ShippingPrice, 'f', 2, 64)
+		params[p+"PriceToEstimateFees.Shipping.CurrencyCode"] = currency
+		if r.Points > 0 {
+			params[p+"PriceToEstimateFees.Points.PointsNumber"] = strconv.Itoa(r.Points)
+		}
+	}
+	return params, nil
+}
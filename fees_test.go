@@ -0,0 +1,71 @@
+package amazonmws
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFeesEstimateParams(t *testing.T) {
+	api := MWSAPI{MarketplaceID: "ATVPDKIKX0DER"}
+	params, err := api.feesEstimateParams([]FeesEstimateRequest{
+		{IDType: "ASIN", IDValue: "B002KT3XQM", IsAmazonFulfilled: true, ListingPrice: 30, ShippingPrice: 3.99},
+		{IDType: "SellerSKU", IDValue: "SKU-1", Identifier: "request-2", MarketplaceID: "A1F83G8C2ARO7P", Points: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"FeesEstimateRequestList.FeesEstimateRequest.1.MarketplaceId":                                 "ATVPDKIKX0DER",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.IdType":                                        "ASIN",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.IdValue":                                       "B002KT3XQM",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.IsAmazonFulfilled":                             "true",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.Identifier":                                    "B002KT3XQM",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.PriceToEstimateFees.ListingPrice.Amount":       "30.00",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.PriceToEstimateFees.Shipping.Amount":           "3.99",
+		"FeesEstimateRequestList.FeesEstimateRequest.1.PriceToEstimateFees.Shipping.CurrencyCode":     "USD",
+		"FeesEstimateRequestList.FeesEstimateRequest.2.MarketplaceId":                                 "A1F83G8C2ARO7P",
+		"FeesEstimateRequestList.FeesEstimateRequest.2.Identifier":                                    "request-2",
+		"FeesEstimateRequestList.FeesEstimateRequest.2.PriceToEstimateFees.ListingPrice.CurrencyCode": "GBP",
+		"FeesEstimateRequestList.FeesEstimateRequest.2.PriceToEstimateFees.Points.PointsNumber":       "10",
+	}
+	for k, v := range want {
+		if params[k] != v {
+			t.Errorf("%s = %q, want %q", k, params[k], v)
+		}
+	}
+	if _, ok := params["FeesEstimateRequestList.FeesEstimateRequest.1.PriceToEstimateFees.Points.PointsNumber"]; ok {
+		t.Error("PointsNumber sent without Points")
+	}
+}
+
+func TestFeesEstimateParamsInvalid(t *testing.T) {
+	api := MWSAPI{MarketplaceID: "ATVPDKIKX0DER"}
+	valid := FeesEstimateRequest{IDType: "ASIN", IDValue: "B002KT3XQM"}
+	tooMany := make([]FeesEstimateRequest, MaxFeesEstimateRequests+1)
+	for i := range tooMany {
+		tooMany[i] = valid
+	}
+	for _, tc := range []struct {
+		name     string
+		requests []FeesEstimateRequest
+		want     string
+	}{
+		{"none", nil, "takes 1 to 20 requests, got 0"},
+		{"too many", tooMany, "takes 1 to 20 requests, got 21"},
+		{"bad IdType", []FeesEstimateRequest{valid, {IDType: "UPC", IDValue: "082676082658"}}, `IdType must be ASIN or SellerSKU, got "UPC"`},
+		{"no IdValue", []FeesEstimateRequest{{IDType: "SellerSKU"}}, "request 1 has no IdValue"},
+		{"unknown currency", []FeesEstimateRequest{{IDType: "ASIN", IDValue: "B1", MarketplaceID: "XXXXXXXX"}}, `no currency for marketplace "XXXXXXXX"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			params, err := api.feesEstimateParams(tc.requests)
+			if err == nil || !strings.Contains(err.Error(), tc.want) || params != nil {
+				t.Fatalf("got %v, %v; want an error containing %q", params, err, tc.want)
+			}
+		})
+	}
+
+	// CurrencyCode makes an unknown marketplace usable.
+	if _, err := api.feesEstimateParams([]FeesEstimateRequest{{IDType: "ASIN", IDValue: "B1", MarketplaceID: "XXXXXXXX", CurrencyCode: "USD"}}); err != nil {
+		t.Fatal(err)
+	}
+}
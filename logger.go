@@ -0,0 +1,33 @@
+package amazonmws
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger receives the package's log records. *slog.Logger satisfies it,
+// so any slog handler can be plugged in. Nothing is logged by default.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(context.Context, slog.Level, string, ...any) {}
+
+// WithLogger sends the client's request and retry records to l.
+// Request records carry operation, seller, marketplace, request_id,
+// attempt, status, throttle_wait and latency fields.
+func WithLogger(l Logger) Option {
+	return func(api *MWSAPI) error {
+		api.logger = l
+		return nil
+	}
+}
+
+func loggerOrNop(l Logger) Logger {
+	if l == nil {
+		return nopLogger{}
+	}
+	return l
+}
@@ -2,7 +2,6 @@ package amazonmws
 
 import (
 	"encoding/xml"
-	"log"
 	"regexp"
 	"strconv"
 )
@@ -56,7 +55,7 @@ func parseMoney(priceStr string) (priceInt int) {
 	p, err := strconv.ParseFloat(priceStr, 64)
 
 	if err != nil {
-		log.Fatal(err)
+		return -1
 	}
 
 	priceInt = int(p * 100.0)
@@ -88,19 +87,18 @@ func parseDomestic(domStr string) bool {
 	return false
 }
 
-func parseFeedbackRating(fbStr string) int {
-	ratingRegex, err := regexp.Compile(`([\d]+)%$`)
-
-	if err != nil {
-		log.Fatal(err)
-	}
+var (
+	ratingRegex = regexp.MustCompile(`([\d]+)%$`)
+	maxRegex    = regexp.MustCompile(`([0-9]+) .*days`)
+)
 
+func parseFeedbackRating(fbStr string) int {
 	if ratingRegex.Match([]byte(fbStr)) {
 		m := ratingRegex.FindStringSubmatch(fbStr)
 		i, err := strconv.Atoi(m[1])
 
 		if err != nil {
-			log.Fatal("Couldn't parse Feedback Rating: ", err)
+			return -1
 		}
 
 		return i
@@ -110,18 +108,12 @@ func parseFeedbackRating(fbStr string) int {
 }
 
 func parseMaxShipping(shipStr string) int {
-	maxRegex, err := regexp.Compile(`([0-9]+) .*days`)
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	if maxRegex.Match([]byte(shipStr)) {
 		m := maxRegex.FindStringSubmatch(shipStr)
 		i, err := strconv.Atoi(m[1])
 
 		if err != nil {
-			log.Fatal("Couldn't parse Max Shipping: ", err)
+			return -1
 		}
 		return i
 	}
@@ -129,15 +121,26 @@ func parseMaxShipping(shipStr string) int {
 	return -1
 }
 
-// Parse parses the xml response for GetLowestOfferListingsForASINResponse()
+// Parse parses the xml response for GetLowestOfferListingsForASINResponse().
+// Malformed XML yields whatever was decoded before the error; prices and
+// ratings that cannot be parsed are -1.
 func Parse(body []byte) (mws Document) {
-	mws = Document{}
+	doc, _ := decodeDocument(body)
+
+	return *doc
+}
+
+func decodeDocument(body []byte) (*Document, error) {
+	mws := Document{}
 
 	if err := xml.Unmarshal(body, &mws); err != nil {
-		log.Fatal(err)
+		return &mws, err
 	}
 
 	for _, result := range mws.Results {
+		if result.Product == nil {
+			continue
+		}
 		for k, o := range result.Product.Offers {
 			result.Product.Offers[k].ListingPrice = parseMoney(o.ListingPriceString)
 			result.Product.Offers[k].ShippingPrice = parseMoney(o.ShippingPriceString)
@@ -150,5 +153,5 @@ func Parse(body []byte) (mws Document) {
 		}
 	}
 
-	return mws
+	return &mws, nil
 }
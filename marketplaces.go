@@ -0,0 +1,167 @@
+package amazonmws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Region groups the marketplaces a seller account can sell in together.
+type Region string
+
+// MWS regions
+const (
+	NorthAmerica Region = "NA"
+	Europe       Region = "EU"
+	FarEast      Region = "FE"
+	China        Region = "CN"
+)
+
+// Marketplace describes an Amazon marketplace and the MWS endpoint serving it.
+type Marketplace struct {
+	ID          string
+	CountryCode string
+	Currency    string
+	Host        string
+	Region      Region
+}
+
+// Marketplaces is the registry of known marketplaces keyed by country code.
+var Marketplaces = map[string]Marketplace{
+	"BR": {"A2Q3Y263D00KWC", "BR", "BRL", "mws.amazonservices.com", NorthAmerica},
+	"CA": {"A2EUQ1WTGCTBG2", "CA", "CAD", "mws.amazonservices.com", NorthAmerica},
+	"MX": {"A1AM78C64UM0Y8", "MX", "MXN", "mws.amazonservices.com", NorthAmerica},
+	"US": {"ATVPDKIKX0DER", "US", "USD", "mws.amazonservices.com", NorthAmerica},
+	"AE": {"A2VIGQ35RCS4UG", "AE", "AED", "mws.amazonservices.ae", Europe},
+	"DE": {"A1PA6795UKMFR9", "DE", "EUR", "mws-eu.amazonservices.com", Europe},
+	"EG": {"ARBP9OOSHTCHU", "EG", "EGP", "mws-eu.amazonservices.com", Europe},
+	"ES": {"A1RKKUPIHCS9HS", "ES", "EUR", "mws-eu.amazonservices.com", Europe},
+	"FR": {"A13V1IB3VIYZZH", "FR", "EUR", "mws-eu.amazonservices.com", Europe},
+	"GB": {"A1F83G8C2ARO7P", "GB", "GBP", "mws-eu.amazonservices.com", Europe},
+	"IN": {"A21TJRUUN4KGV", "IN", "INR", "mws.amazonservices.in", Europe},
+	"IT": {"APJ6JRA9NG5V4", "IT", "EUR", "mws-eu.amazonservices.com", Europe},
+	"NL": {"A1805IZSGTT6HS", "NL", "EUR", "mws-eu.amazonservices.com", Europe},
+	"SA": {"A17E79C6D8DWNP", "SA", "SAR", "mws-eu.amazonservices.com", Europe},
+	"TR": {"A33AVAJ2PDY3EV", "TR", "TRY", "mws-eu.amazonservices.com", Europe},
+	"SG": {"A19VAU5U5O7RUS", "SG", "SGD", "mws-fe.amazonservices.com", FarEast},
+	"AU": {"A39IBJ37TRP1C6", "AU", "AUD", "mws.amazonservices.com.au", FarEast},
+	"JP": {"A1VC38T7YXB528", "JP", "JPY", "mws.amazonservices.jp", FarEast},
+	"CN": {"AAHKV2X7AFYLW", "CN", "CNY", "mws.amazonservices.com.cn", China},
+}
+
+// LookupMarketplace returns the marketplace for a country code such as "US".
+// "UK" is accepted for GB.
+func LookupMarketplace(code string) (Marketplace, error) {
+	code = strings.ToUpper(code)
+	if code == "UK" {
+		code = "GB"
+	}
+	m, ok := Marketplaces[code]
+	if !ok {
+		return Marketplace{}, fmt.Errorf("amazonmws: unknown marketplace %q", code)
+	}
+	return m, nil
+}
+
+// MarketplaceByID returns the marketplace with the given MarketplaceId.
+func MarketplaceByID(id string) (Marketplace, bool) {
+	for _, m := range Marketplaces {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return Marketplace{}, false
+}
+
+// NewMWSAPIForMarketplace is NewMWSAPI with the Host and MarketplaceID of
+// config filled in from the marketplace registered for code.
+func NewMWSAPIForMarketplace(code string, config MWSAPI, opts ...Option) (*MWSAPI, error) {
+	m, err := LookupMarketplace(code)
+	if err != nil {
+		return nil, err
+	}
+	config.Host = m.Host
+	config.MarketplaceID = m.ID
+	api, err := NewMWSAPI(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.Validate(); err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// Validate checks that MarketplaceID is a registered marketplace served
+// by Host. The Host check is skipped once WithBaseURL has overridden it.
+func (api MWSAPI) Validate() error {
+	m, ok := MarketplaceByID(api.MarketplaceID)
+	if !ok {
+		return fmt.Errorf("amazonmws: unknown MarketplaceID %q", api.MarketplaceID)
+	}
+	if api.scheme == "" && !strings.EqualFold(api.Host, m.Host) {
+		return fmt.Errorf("amazonmws: MarketplaceID %s (%s) is served by %s, not %s", m.ID, m.CountryCode, m.Host, api.Host)
+	}
+	return nil
+}
+
+// resolveMarketplace finds a marketplace by country code or MarketplaceId.
+func resolveMarketplace(codeOrID string) (Marketplace, bool) {
+	if m, err := LookupMarketplace(codeOrID); err == nil {
+		return m, true
+	}
+	return MarketplaceByID(codeOrID)
+}
+
+// ForMarketplace returns a copy of api that sends MarketplaceId for the
+// marketplace named by codeOrID, a country code or MarketplaceId, so a
+// single call can target it:
+//
+//	ca, err := api.ForMarketplace("CA")
+//	resp, err := ca.GetMyPriceForSKUParsed(ctx, skus)
+//
+// The copy shares the client's RateLimiter and HTTP client. The marketplace
+// must be served by api.Host unless WithBaseURL has overridden it.
+func (api MWSAPI) ForMarketplace(codeOrID string) (MWSAPI, error) {
+	m, ok := resolveMarketplace(codeOrID)
+	if !ok {
+		return api, fmt.Errorf("amazonmws: unknown marketplace %q", codeOrID)
+	}
+	if api.scheme == "" && !strings.EqualFold(api.Host, m.Host) {
+		return api, fmt.Errorf("amazonmws: marketplace %s is served by %s, not %s", m.CountryCode, m.Host, api.Host)
+	}
+	api.MarketplaceID = m.ID
+	return api, nil
+}
+
+// MarketplaceResult is one marketplace's outcome from FanOutMarketplaces.
+type MarketplaceResult struct {
+	Marketplace Marketplace
+	Result      interface{}
+	Err         error
+}
+
+// FanOutMarketplaces runs fn concurrently with a copy of api for each of
+// markets (country codes or MarketplaceIds) and returns the results in the
+// same order. Every marketplace must be served by api.Host.
+func (api MWSAPI) FanOutMarketplaces(ctx context.Context, markets []string, fn func(ctx context.Context, api MWSAPI) (interface{}, error)) []MarketplaceResult {
+	results := make([]MarketplaceResult, len(markets))
+	var wg sync.WaitGroup
+	for i, market := range markets {
+		m, _ := resolveMarketplace(market)
+		results[i].Marketplace = m
+		local, err := api.ForMarketplace(market)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(r *MarketplaceResult) {
+			defer wg.Done()
+			r.Result, r.Err = fn(ctx, local)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,216 @@
+package amazonmws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request
+// latency histogram.
+var DefaultLatencyBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// DefaultWaitBuckets are the upper bounds, in seconds, of the throttle
+// wait histogram. They reach further than latency since hourly quotas can
+// hold a request for minutes.
+var DefaultWaitBuckets = []float64{.01, .1, .5, 1, 5, 10, 30, 60, 300}
+
+// Metrics collects per-operation counters and histograms for every attempt
+// the client sends:
+//
+//	mws_requests_total{operation,status}
+//	mws_request_duration_seconds{operation}
+//	mws_throttle_wait_seconds{operation}
+//	mws_retries_total{operation}
+//	mws_errors_total{operation,code}
+//
+// Error codes outside the ones defined in errors.go are counted as
+// "Other", and failures without an MWS response as "Transport". Metrics
+// implements http.Handler, serving the Prometheus text format, so it can
+// be mounted on /metrics as is.
+type Metrics struct {
+	mu           sync.Mutex
+	requests     map[[2]string]uint64
+	latency      map[string]*histogram
+	throttleWait map[string]*histogram
+	retries      map[string]uint64
+	errors       map[[2]string]uint64
+
+	latencyBuckets []float64
+	waitBuckets    []float64
+}
+
+// NewMetrics returns an empty Metrics using DefaultLatencyBuckets and
+// DefaultWaitBuckets.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:       make(map[[2]string]uint64),
+		latency:        make(map[string]*histogram),
+		throttleWait:   make(map[string]*histogram),
+		retries:        make(map[string]uint64),
+		errors:         make(map[[2]string]uint64),
+		latencyBuckets: DefaultLatencyBuckets,
+		waitBuckets:    DefaultWaitBuckets,
+	}
+}
+
+// WithMetrics records the client's requests into m. One Metrics may be
+// shared by several clients, e.g. every seller of a SellerPool.
+func WithMetrics(m *Metrics) Option {
+	return func(api *MWSAPI) error {
+		api.metrics = m
+		return nil
+	}
+}
+
+// Middleware returns the Middleware that records into m. The client
+// installs it inside its rate limit middleware, so ThrottleWait is known.
+func (m *Metrics) Middleware() Middleware {
+	return m.middleware(systemClock{})
+}
+
+func (m *Metrics) middleware(clock Clock) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			start := clock.Now()
+			reply, err := next(ctx, call)
+			m.observe(call, reply, err, clock.Now().Sub(start))
+			return reply, err
+		}
+	}
+}
+
+func (m *Metrics) observe(call *Call, reply *Reply, err error, latency time.Duration) {
+	op := call.Operation
+	status := "error"
+	if reply != nil {
+		status = strconv.Itoa(reply.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[[2]string{op, status}]++
+	observeHistogram(m.latency, op, m.latencyBuckets, latency)
+	observeHistogram(m.throttleWait, op, m.waitBuckets, call.ThrottleWait)
+	if call.Attempt > 1 {
+		m.retries[op]++
+	}
+	if err != nil {
+		m.errors[[2]string{op, errorCode(err)}]++
+	}
+}
+
+// metricCodes are the MWS error codes given their own label value.
+var metricCodes = []string{
+	Disconnect, Parameter, AccessDenied, InvalidAccessKey, SignDoesNotMatch,
+	InvalidAddress, RequestExpired, InternalError, QuotaExceeded, ReqThrottled,
+	AccessToReportDenied, InvalidReportID, InvalidReportType, InvalidRequest,
+	InvalidScheduleFrequency, ReportNoLongerAvailable, ReportNotReady,
+}
+
+func errorCode(err error) string {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return "Transport"
+	}
+	for _, c := range metricCodes {
+		if apiErr.Code == c {
+			return c
+		}
+	}
+	return "Other"
+}
+
+type histogram struct {
+	bounds []float64
+	counts []uint64 /*cumulative, one per bound*/
+	count  uint64
+	sum    float64
+}
+
+func observeHistogram(m map[string]*histogram, op string, bounds []float64, d time.Duration) {
+	h, ok := m[op]
+	if !ok {
+		h = &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+		m[op] = h
+	}
+	v := d.Seconds()
+	for i, b := range h.bounds {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.mu.Lock()
+	writeCounter(&b, "mws_requests_total", "MWS requests sent, by operation and HTTP status.", []string{"operation", "status"}, m.requests)
+	writeHistograms(&b, "mws_request_duration_seconds", "Time from sending an MWS request to reading its response.", m.latency)
+	writeHistograms(&b, "mws_throttle_wait_seconds", "Time MWS requests waited in the rate limiter.", m.throttleWait)
+	retries := make(map[[2]string]uint64, len(m.retries))
+	for op, n := range m.retries {
+		retries[[2]string{op}] = n
+	}
+	writeCounter(&b, "mws_retries_total", "MWS requests that were retries of a failed attempt.", []string{"operation"}, retries)
+	writeCounter(&b, "mws_errors_total", "Failed MWS requests, by operation and MWS error code.", []string{"operation", "code"}, m.errors)
+	m.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the output of WriteTo.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+func writeCounter(b *strings.Builder, name, help string, labels []string, values map[[2]string]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	keys := make([][2]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		pairs := make([]string, len(labels))
+		for i, l := range labels {
+			pairs[i] = fmt.Sprintf("%s=%q", l, k[i])
+		}
+		fmt.Fprintf(b, "%s{%s} %d\n", name, strings.Join(pairs, ","), values[k])
+	}
+}
+
+func writeHistograms(b *strings.Builder, name, help string, values map[string]*histogram) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	ops := make([]string, 0, len(values))
+	for op := range values {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	for _, op := range ops {
+		h := values[op]
+		for i, bound := range h.bounds {
+			fmt.Fprintf(b, "%s_bucket{operation=%q,le=%q} %d\n", name, op, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(b, "%s_bucket{operation=%q,le=\"+Inf\"} %d\n", name, op, h.count)
+		fmt.Fprintf(b, "%s_sum{operation=%q} %g\n", name, op, h.sum)
+		fmt.Fprintf(b, "%s_count{operation=%q} %d\n", name, op, h.count)
+	}
+}
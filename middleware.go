@@ -0,0 +1,109 @@
+package amazonmws
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Call is one MWS request on its way through the middleware chain.
+// Middlewares may change Params or add to Header before calling next;
+// the request is signed only once it reaches the end of the chain.
+type Call struct {
+	Operation string
+	Path      string
+	Params    map[string]string
+	SellerID  string
+	Header    http.Header /*extra request headers*/
+	// Stream, if set, is opened for a 2xx response and the body is copied
+	// into it instead of being kept in Reply.Body. It is opened again for
+	// every successful attempt, so it should truncate.
+	Stream func() (io.WriteCloser, error)
+
+	Attempt      int           /*set by the retry middleware, from 1*/
+	ThrottleWait time.Duration /*set by the rate limit middleware*/
+}
+
+// Reply is the raw MWS response to a Call. Body is empty when the Call
+// streamed a 2xx response.
+type Reply struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Handler performs a Call. When MWS answers with an error, the Reply is
+// returned along with the *APIError.
+type Handler func(ctx context.Context, call *Call) (*Reply, error)
+
+// Middleware wraps a Handler with extra behaviour.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds mw to the client's chain. They run in order, outside
+// the built-in retry, rate limit, metrics and logging middlewares, so they
+// see each Call once however many attempts it takes.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(api *MWSAPI) error {
+		api.middleware = append(append([]Middleware(nil), api.middleware...), mw...)
+		return nil
+	}
+}
+
+// handler assembles the client's chain:
+// WithMiddleware, then retry, rate limit, metrics, logging and finally send.
+func (api MWSAPI) handler() Handler {
+	clock := clockOrSystem(api.clock)
+	h := Handler(api.send)
+	h = loggingMiddleware(api.logger, clock)(h)
+	if api.metrics != nil {
+		h = api.metrics.middleware(clock)(h)
+	}
+	if l := api.rateLimiter(); l != nil {
+		h = rateLimitMiddleware(l, clock)(h)
+	}
+	p := DefaultRetryPolicy
+	if api.retryPolicy != nil {
+		p = *api.retryPolicy
+	}
+	h = retryMiddleware(p, clock, api.skew, api.logger)(h)
+	for i := len(api.middleware) - 1; i >= 0; i-- {
+		h = api.middleware[i](h)
+	}
+	return h
+}
+
+// LoggingMiddleware logs every attempt to l: failures at Warn, other
+// requests at Debug, with operation, seller, marketplace, attempt, status,
+// request_id, throttle_wait and latency fields.
+func LoggingMiddleware(l Logger) Middleware {
+	return loggingMiddleware(l, systemClock{})
+}
+
+func loggingMiddleware(l Logger, clock Clock) Middleware {
+	logger := loggerOrNop(l)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			start := clock.Now()
+			reply, err := next(ctx, call)
+			fields := []any{
+				"operation", call.Operation,
+				"seller", call.SellerID,
+				"marketplace", call.Params["MarketplaceId"],
+				"attempt", call.Attempt,
+				"throttle_wait", call.ThrottleWait,
+				"latency", clock.Now().Sub(start),
+			}
+			if reply != nil {
+				fields = append(fields, "status", reply.StatusCode, "request_id", reply.Header.Get("x-mws-request-id"))
+			}
+			if err != nil {
+				logger.Log(ctx, slog.LevelWarn, "mws request failed", append(fields, "error", err)...)
+			} else {
+				logger.Log(ctx, slog.LevelDebug, "mws request", fields...)
+			}
+			return reply, err
+		}
+	}
+}
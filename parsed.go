@@ -0,0 +1,167 @@
+package amazonmws
+
+import (
+	"context"
+
+	cats "github.com/rdorrigan/mws/parsers/cats"
+	"github.com/rdorrigan/mws/parsers/cp"
+	"github.com/rdorrigan/mws/parsers/fees"
+	"github.com/rdorrigan/mws/parsers/lmp"
+	lowoff "github.com/rdorrigan/mws/parsers/lowoff"
+	"github.com/rdorrigan/mws/parsers/lowp"
+	"github.com/rdorrigan/mws/parsers/mp"
+	"github.com/rdorrigan/mws/parsers/mpfid"
+	"github.com/rdorrigan/mws/parsers/reports/getrepreqlist"
+	"github.com/rdorrigan/mws/parsers/reports/reportrequest"
+)
+
+// The Parsed operations send the same request as their raw counterparts,
+// return any *APIError from the fetch path, and decode the body
+// with the matching parsers package.
+
+// GetLowestOfferListingsForASINParsed is GetLowestOfferListingsForASIN decoded into a Document.
+func (api MWSAPI) GetLowestOfferListingsForASINParsed(ctx context.Context, items []string) (*Document, error) {
+	body, err := api.fetch(ctx, "GetLowestOfferListingsForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(body)
+}
+
+// ListMatchingProductsParsed is ListMatchingProducts decoded into an lmp.XMLResponse.
+func (api MWSAPI) ListMatchingProductsParsed(ctx context.Context, query string, queryContextID string) (*lmp.XMLResponse, error) {
+	body, err := api.fetch(ctx, "ListMatchingProducts", prodAPI, api.listMatchingProductsParams(query, queryContextID))
+	if err != nil {
+		return nil, err
+	}
+	return lmp.Decode(body)
+}
+
+// GetMatchingProductForIDParsed is GetMatchingProductForID decoded into an mpfid.XMLResponse.
+// Use its ASINMap to map the identifiers to ASINs.
+func (api MWSAPI) GetMatchingProductForIDParsed(ctx context.Context, idType string, idList []string) (*mpfid.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetMatchingProductForId", prodAPI, api.matchingProductForIDParams(idType, idList))
+	if err != nil {
+		return nil, err
+	}
+	return mpfid.Decode(body)
+}
+
+// GetMyPriceForSKUParsed is GetMyPriceForSKU decoded into an mp.XMLResponse.
+func (api MWSAPI) GetMyPriceForSKUParsed(ctx context.Context, items []string) (*mp.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetMyPriceForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
+	if err != nil {
+		return nil, err
+	}
+	return mp.Decode(body)
+}
+
+// GetLowestOfferListingsForSKUParsed is GetLowestOfferListingsForSKU decoded
+// into a parsers/lowoff XMLResponse.
+func (api MWSAPI) GetLowestOfferListingsForSKUParsed(ctx context.Context, items []string) (*lowoff.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetLowestOfferListingsForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
+	if err != nil {
+		return nil, err
+	}
+	return lowoff.Decode(body)
+}
+
+// GetLowestPricedOffersForSKUParsed is GetLowestPricedOffersForSKU decoded into a lowp.XMLResponse.
+func (api MWSAPI) GetLowestPricedOffersForSKUParsed(ctx context.Context, item string) (*lowp.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetLowestPricedOffersForSKU", prodAPI, api.lowestPricedOffersForSKUParams(item))
+	if err != nil {
+		return nil, err
+	}
+	return lowp.Decode(body)
+}
+
+// GetProductCategoriesForSKUParsed is GetProductCategoriesForSKU decoded
+// into a parsers/cats XMLResponse.
+func (api MWSAPI) GetProductCategoriesForSKUParsed(ctx context.Context, item string) (*cats.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetProductCategoriesForSKU", prodAPI, api.productCategoriesForSKUParams(item))
+	if err != nil {
+		return nil, err
+	}
+	return cats.Decode(body)
+}
+
+// GetMyPriceForASINParsed is GetMyPriceForASIN decoded into an mp.ASINResponse.
+func (api MWSAPI) GetMyPriceForASINParsed(ctx context.Context, items []string) (*mp.ASINResponse, error) {
+	body, err := api.fetch(ctx, "GetMyPriceForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
+	if err != nil {
+		return nil, err
+	}
+	return mp.DecodeASIN(body)
+}
+
+// GetCompetitivePricingForASINParsed is GetCompetitivePricingForASIN decoded into a cp.ASINResponse.
+func (api MWSAPI) GetCompetitivePricingForASINParsed(ctx context.Context, items []string) (*cp.ASINResponse, error) {
+	body, err := api.fetch(ctx, "GetCompetitivePricingForASIN", prodAPI, api.listParams("ASINList.ASIN", items))
+	if err != nil {
+		return nil, err
+	}
+	return cp.DecodeASIN(body)
+}
+
+// GetCompetitivePricingForSKUParsed is GetCompetitivePricingForSKU decoded into a cp.XMLResponse.
+func (api MWSAPI) GetCompetitivePricingForSKUParsed(ctx context.Context, items []string) (*cp.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetCompetitivePricingForSKU", prodAPI, api.listParams("SellerSKUList.SellerSKU", items))
+	if err != nil {
+		return nil, err
+	}
+	return cp.Decode(body)
+}
+
+// GetLowestPricedOffersForASINParsed is GetLowestPricedOffersForASIN decoded into a lowp.ASINResponse.
+func (api MWSAPI) GetLowestPricedOffersForASINParsed(ctx context.Context, item string) (*lowp.ASINResponse, error) {
+	body, err := api.fetch(ctx, "GetLowestPricedOffersForASIN", prodAPI, api.lowestPricedOffersForASINParams(item))
+	if err != nil {
+		return nil, err
+	}
+	return lowp.DecodeASIN(body)
+}
+
+// GetProductCategoriesForASINParsed is GetProductCategoriesForASIN decoded
+// into a parsers/cats ASINResponse.
+func (api MWSAPI) GetProductCategoriesForASINParsed(ctx context.Context, item string) (*cats.ASINResponse, error) {
+	body, err := api.fetch(ctx, "GetProductCategoriesForASIN", prodAPI, api.productCategoriesForASINParams(item))
+	if err != nil {
+		return nil, err
+	}
+	return cats.DecodeASIN(body)
+}
+
+// GetMyFeesEstimateParsed is GetMyFeesEstimate decoded into a fees.XMLResponse.
+func (api MWSAPI) GetMyFeesEstimateParsed(ctx context.Context, requests []FeesEstimateRequest) (*fees.XMLResponse, error) {
+	params, err := api.feesEstimateParams(requests)
+	if err != nil {
+		return nil, err
+	}
+	body, err := api.fetch(ctx, "GetMyFeesEstimate", prodAPI, params)
+	if err != nil {
+		return nil, err
+	}
+	return fees.Decode(body)
+}
+
+// RequestReportParsed is RequestReport decoded into a reportrequest.XMLResponse.
+func (api MWSAPI) RequestReportParsed(ctx context.Context, report string, dateparams []string) (*reportrequest.XMLResponse, error) {
+	params, err := api.requestReportParams(report, dateparams)
+	if err != nil {
+		return nil, err
+	}
+	body, err := api.fetch(ctx, "RequestReport", reportAPI, params)
+	if err != nil {
+		return nil, err
+	}
+	return reportrequest.Decode(body)
+}
+
+// GetReportRequestListParsed is GetReportRequestList decoded into a getrepreqlist.XMLResponse.
+func (api MWSAPI) GetReportRequestListParsed(ctx context.Context, params map[string]string) (*getrepreqlist.XMLResponse, error) {
+	body, err := api.fetch(ctx, "GetReportRequestList", reportAPI, api.reportRequestListParams(params))
+	if err != nil {
+		return nil, err
+	}
+	return getrepreqlist.Decode(body)
+}
@@ -2,7 +2,6 @@ package low
 
 import (
 	"encoding/xml"
-	"log"
 	"sync"
 )
 
@@ -23,13 +22,21 @@ func NewXMLParser() *XMLParser {
 	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
 }
 
-// Parser parses the xml response for MWS Products operations
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
 func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
 	var i XMLResponse
 	if err := xml.Unmarshal(body, &i); err != nil {
-		log.Println(err)
+		return &i, err
 	}
-	return &i
+	return &i, nil
 }
 
 // XMLResponse contains the XML results of the func GetLowestOfferListingsForSKU
@@ -39,10 +46,27 @@ type XMLResponse struct {
 	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
 }
 
-// XMLResult is the xml container for GetProductCategoriesForSKU() Responses
+// ASINResponse contains the XML results of the func GetProductCategoriesForASIN
+type ASINResponse struct {
+	XMLName          xml.Name         `xml:"GetProductCategoriesForASINResponse"`
+	Results          XMLResult        `xml:"GetProductCategoriesForASINResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// DecodeASIN is Decode for GetProductCategoriesForASIN, whose result shares XMLResult.
+func DecodeASIN(body []byte) (*ASINResponse, error) {
+	var i ASINResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// XMLResult is the xml container for GetProductCategoriesForSKU() and
+// GetProductCategoriesForASIN() Responses
 type XMLResult struct {
-	XMLName xml.Name `xml:"GetProductCategoriesForSKUResult"`
-	Self    []Self   `xml:"Self"`
+	XMLName xml.Name
+	Self    []Self `xml:"Self"`
 	TooSoon bool
 }
 
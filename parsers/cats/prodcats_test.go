@@ -0,0 +1,27 @@
+package low
+
+import "testing"
+
+func TestDecodeASIN(t *testing.T) {
+	r, err := DecodeASIN([]byte(`<?xml version="1.0"?>
+<GetProductCategoriesForASINResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetProductCategoriesForASINResult><Self><ProductCategoryId>2420095011</ProductCategoryId><ProductCategoryName>Laundry Bags</ProductCategoryName>
+<Parent><ProductCategoryId>2420094011</ProductCategoryId><ProductCategoryName>Laundry Storage &amp; Organization</ProductCategoryName></Parent></Self></GetProductCategoriesForASINResult>
+<ResponseMetadata><RequestId>fbce5b62-EXAMPLE</RequestId></ResponseMetadata></GetProductCategoriesForASINResponse>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results.Self) != 1 || r.ResponseMetadata.RequestID != "fbce5b62-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+	self := r.Results.Self[0]
+	if self.ProductCategoryID != "2420095011" || self.ProductCategoryName != "Laundry Bags" || len(self.Parents) != 1 {
+		t.Fatalf("self = %+v", self)
+	}
+
+	// The SKU response shares XMLResult.
+	s, err := Decode([]byte(`<GetProductCategoriesForSKUResponse><GetProductCategoriesForSKUResult><Self><ProductCategoryId>2</ProductCategoryId></Self></GetProductCategoriesForSKUResult></GetProductCategoriesForSKUResponse>`))
+	if err != nil || len(s.Results.Self) != 1 || s.Results.Self[0].ProductCategoryID != "2" {
+		t.Fatalf("Decode = %+v, %v", s, err)
+	}
+}
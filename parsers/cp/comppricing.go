@@ -0,0 +1,153 @@
+package cp
+
+import (
+	"encoding/xml"
+	"sync"
+
+	"github.com/rdorrigan/mws/parsers/lmp"
+)
+
+// XMLParse extends Parser
+type XMLParse interface {
+	Parser(body []byte)
+}
+
+// XMLParser represents an XML parser.
+type XMLParser struct {
+	decoder  *xml.Decoder
+	decMutex *sync.Mutex
+	mapMutex *sync.Mutex
+}
+
+// NewXMLParser creates a new XML parser.
+func NewXMLParser() *XMLParser {
+	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
+}
+
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
+func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
+	var i XMLResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// DecodeASIN is Decode for GetCompetitivePricingForASIN, whose results share XMLResult.
+func DecodeASIN(body []byte) (*ASINResponse, error) {
+	var i ASINResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// CompetitivePriceId values
+const (
+	NewBuyBox  = "1"
+	UsedBuyBox = "2"
+)
+
+// XMLResponse contains the XML results of the func GetCompetitivePricingForSKU()
+type XMLResponse struct {
+	XMLName          xml.Name         `xml:"GetCompetitivePricingForSKUResponse"`
+	Results          []XMLResult      `xml:"GetCompetitivePricingForSKUResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// ASINResponse contains the XML results of the func GetCompetitivePricingForASIN()
+type ASINResponse struct {
+	XMLName          xml.Name         `xml:"GetCompetitivePricingForASINResponse"`
+	Results          []XMLResult      `xml:"GetCompetitivePricingForASINResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// XMLResult is the xml container for GetCompetitivePricingForSKU() and
+// GetCompetitivePricingForASIN() Responses. SellerSKU is set for the former, ASIN for the latter.
+type XMLResult struct {
+	XMLName   xml.Name
+	ASIN      string `xml:"ASIN,attr"`
+	SellerSKU string `xml:"SellerSKU,attr"`
+	Status    string `xml:"status,attr"`
+	Product   Product
+}
+
+// ResponseMetadata returns a RequestID
+type ResponseMetadata struct {
+	XMLName   xml.Name `xml:"ResponseMetadata"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// Product describes a Products Identifiers, CompetitivePricing & SalesRankings
+type Product struct {
+	XMLName               xml.Name            `xml:"Product"`
+	Identifiers           Identifier          `xml:"Identifiers"`
+	CompetitivePrices     []CompetitivePrice  `xml:"CompetitivePricing>CompetitivePrices>CompetitivePrice"`
+	NumberOfOfferListings []OfferListingCount `xml:"CompetitivePricing>NumberOfOfferListings>OfferListingCount"`
+	TradeInValue          Money               `xml:"CompetitivePricing>TradeInValue"`
+	SalesRankings         []lmp.SalesRanking  `xml:"SalesRankings>SalesRank"`
+}
+
+// Price returns the CompetitivePrice with competitivePriceID, e.g. NewBuyBox
+func (p Product) Price(competitivePriceID string) (CompetitivePrice, bool) {
+	for _, c := range p.CompetitivePrices {
+		if c.CompetitivePriceID == competitivePriceID {
+			return c, true
+		}
+	}
+	return CompetitivePrice{}, false
+}
+
+// OfferListings returns the number of offer listings in condition, e.g. New or Any
+func (p Product) OfferListings(condition string) int {
+	for _, o := range p.NumberOfOfferListings {
+		if o.Condition == condition {
+			return o.Count
+		}
+	}
+	return 0
+}
+
+// Identifier describes ASIN & SellerSKU.
+type Identifier struct {
+	XMLName       xml.Name `xml:"Identifiers"`
+	MarketplaceID string   `xml:"MarketplaceASIN>MarketplaceId"`
+	ASIN          string   `xml:"MarketplaceASIN>ASIN"`
+	SKU           string   `xml:"SKUIdentifier>SellerSKU"`
+}
+
+// CompetitivePrice is one of the competitive prices of a Product.
+// CompetitivePriceId 1 is the New Buy Box price, 2 the Used Buy Box price.
+// BelongsToRequester is set when the price is the requesting seller's own offer.
+type CompetitivePrice struct {
+	XMLName            xml.Name `xml:"CompetitivePrice"`
+	BelongsToRequester bool     `xml:"belongsToRequester,attr"`
+	Condition          string   `xml:"condition,attr"`
+	Subcondition       string   `xml:"subcondition,attr"`
+	CompetitivePriceID string   `xml:"CompetitivePriceId"`
+	LandedPrice        string   `xml:"Price>LandedPrice>Amount"`
+	CurrencyCode       string   `xml:"Price>LandedPrice>CurrencyCode"`
+	ListingPrice       string   `xml:"Price>ListingPrice>Amount"`
+	ShippingPrice      string   `xml:"Price>Shipping>Amount"`
+}
+
+// OfferListingCount is the number of offer listings in one condition
+type OfferListingCount struct {
+	XMLName   xml.Name `xml:"OfferListingCount"`
+	Condition string   `xml:"condition,attr"`
+	Count     int      `xml:",chardata"`
+}
+
+// Money has currency and an amount
+type Money struct {
+	CurrencyCode string `xml:"CurrencyCode"`
+	Amount       string `xml:"Amount"`
+}
@@ -0,0 +1,72 @@
+package cp
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	r, err := Decode([]byte(`<?xml version="1.0"?>
+<GetCompetitivePricingForSKUResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetCompetitivePricingForSKUResult SellerSKU="SKU2468" status="Success"><Product>
+<Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>1933890517</ASIN></MarketplaceASIN><SKUIdentifier><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><SellerId>A1IMEXAMPLEWRC</SellerId><SellerSKU>SKU2468</SellerSKU></SKUIdentifier></Identifiers>
+<CompetitivePricing><CompetitivePrices><CompetitivePrice belongsToRequester="false" condition="Used" subcondition="Good"><CompetitivePriceId>2</CompetitivePriceId><Price><LandedPrice><CurrencyCode>USD</CurrencyCode><Amount>15.99</Amount></LandedPrice><ListingPrice><CurrencyCode>USD</CurrencyCode><Amount>11.00</Amount></ListingPrice><Shipping><CurrencyCode>USD</CurrencyCode><Amount>4.99</Amount></Shipping></Price></CompetitivePrice></CompetitivePrices></CompetitivePricing>
+</Product></GetCompetitivePricingForSKUResult>
+<ResponseMetadata><RequestId>b2b11a86-EXAMPLE</RequestId></ResponseMetadata></GetCompetitivePricingForSKUResponse>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 1 || r.ResponseMetadata.RequestID != "b2b11a86-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+	res := r.Results[0]
+	if res.SellerSKU != "SKU2468" || res.Product.Identifiers.ASIN != "1933890517" || res.Product.Identifiers.SKU != "SKU2468" {
+		t.Fatalf("result = %+v", res)
+	}
+	p := res.Product.CompetitivePrices
+	if len(p) != 1 || p[0].CompetitivePriceID != "2" || p[0].LandedPrice != "15.99" || p[0].ListingPrice != "11.00" || p[0].ShippingPrice != "4.99" {
+		t.Fatalf("prices = %+v", p)
+	}
+}
+
+func TestDecodeASIN(t *testing.T) {
+	r, err := DecodeASIN([]byte(`<?xml version="1.0"?>
+<GetCompetitivePricingForASINResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetCompetitivePricingForASINResult ASIN="B002L7A1D2" status="Success"><Product xmlns:ns2="http://mws.amazonservices.com/schema/Products/2011-10-01/default.xsd">
+<Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B002L7A1D2</ASIN></MarketplaceASIN></Identifiers>
+<CompetitivePricing><CompetitivePrices>
+<CompetitivePrice belongsToRequester="false" condition="New" subcondition="New"><CompetitivePriceId>1</CompetitivePriceId><Price><LandedPrice><CurrencyCode>USD</CurrencyCode><Amount>15.99</Amount></LandedPrice><ListingPrice><CurrencyCode>USD</CurrencyCode><Amount>15.99</Amount></ListingPrice><Shipping><CurrencyCode>USD</CurrencyCode><Amount>0.00</Amount></Shipping></Price></CompetitivePrice>
+<CompetitivePrice belongsToRequester="true" condition="Used" subcondition="Good"><CompetitivePriceId>2</CompetitivePriceId><Price><LandedPrice><CurrencyCode>USD</CurrencyCode><Amount>9.00</Amount></LandedPrice><ListingPrice><CurrencyCode>USD</CurrencyCode><Amount>5.01</Amount></ListingPrice><Shipping><CurrencyCode>USD</CurrencyCode><Amount>3.99</Amount></Shipping></Price></CompetitivePrice>
+</CompetitivePrices>
+<NumberOfOfferListings><OfferListingCount condition="Any">67</OfferListingCount><OfferListingCount condition="Used">49</OfferListingCount><OfferListingCount condition="New">18</OfferListingCount></NumberOfOfferListings>
+<TradeInValue><CurrencyCode>USD</CurrencyCode><Amount>17.05</Amount></TradeInValue></CompetitivePricing>
+<SalesRankings><SalesRank><ProductCategoryId>book_display_on_website</ProductCategoryId><Rank>4</Rank></SalesRank><SalesRank><ProductCategoryId>271578011</ProductCategoryId><Rank>1</Rank></SalesRank></SalesRankings>
+</Product></GetCompetitivePricingForASINResult>
+<ResponseMetadata><RequestId>b2b11a86-EXAMPLE</RequestId></ResponseMetadata></GetCompetitivePricingForASINResponse>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 1 || r.Results[0].ASIN != "B002L7A1D2" || r.ResponseMetadata.RequestID != "b2b11a86-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+	p := r.Results[0].Product
+
+	bb, ok := p.Price(NewBuyBox)
+	if !ok || bb.BelongsToRequester || bb.Condition != "New" || bb.LandedPrice != "15.99" {
+		t.Fatalf("NewBuyBox = %+v, %v", bb, ok)
+	}
+	used, ok := p.Price(UsedBuyBox)
+	if !ok || !used.BelongsToRequester || used.Subcondition != "Good" || used.ListingPrice != "5.01" || used.ShippingPrice != "3.99" {
+		t.Fatalf("UsedBuyBox = %+v, %v", used, ok)
+	}
+	if _, ok := p.Price("3"); ok {
+		t.Fatal("found a CompetitivePriceId that is not in the response")
+	}
+
+	if p.OfferListings("Any") != 67 || p.OfferListings("Used") != 49 || p.OfferListings("New") != 18 || p.OfferListings("Collectible") != 0 {
+		t.Fatalf("offer listings = %+v", p.NumberOfOfferListings)
+	}
+	if p.TradeInValue.Amount != "17.05" || p.TradeInValue.CurrencyCode != "USD" {
+		t.Fatalf("trade-in value = %+v", p.TradeInValue)
+	}
+	if len(p.SalesRankings) != 2 || p.SalesRankings[0].ProductCategoryID != "book_display_on_website" || p.SalesRankings[1].Rank != 1 {
+		t.Fatalf("sales rankings = %+v", p.SalesRankings)
+	}
+}
@@ -0,0 +1,145 @@
+package fees
+
+import (
+	"encoding/xml"
+	"sync"
+)
+
+// XMLParse extends Parser
+type XMLParse interface {
+	Parser(body []byte)
+}
+
+// XMLParser represents an XML parser.
+type XMLParser struct {
+	decoder  *xml.Decoder
+	decMutex *sync.Mutex
+	mapMutex *sync.Mutex
+}
+
+// NewXMLParser creates a new XML parser.
+func NewXMLParser() *XMLParser {
+	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
+}
+
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
+func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
+	var i XMLResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// FeeType values of a FeeDetail
+const (
+	ReferralFee        = "ReferralFee"
+	VariableClosingFee = "VariableClosingFee"
+	PerItemFee         = "PerItemFee"
+	FBAFees            = "FBAFees"
+)
+
+// Success is the Status of an estimated FeesEstimateResult
+const Success = "Success"
+
+// XMLResponse contains the XML results of the func GetMyFeesEstimate()
+type XMLResponse struct {
+	XMLName          xml.Name         `xml:"GetMyFeesEstimateResponse"`
+	Results          []XMLResult      `xml:"GetMyFeesEstimateResult>FeesEstimateResultList>FeesEstimateResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// XMLResult is the estimate for one FeesEstimateRequest
+type XMLResult struct {
+	XMLName      xml.Name     `xml:"FeesEstimateResult"`
+	Status       string       `xml:"Status"`
+	Identifier   Identifier   `xml:"FeesEstimateIdentifier"`
+	FeesEstimate FeesEstimate `xml:"FeesEstimate"`
+	Error        Error        `xml:"Error"`
+}
+
+// ResponseMetadata returns a RequestID
+type ResponseMetadata struct {
+	XMLName   xml.Name `xml:"ResponseMetadata"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// Identifier echoes the request the estimate is for.
+// SellerInputIdentifier is the Identifier that was sent.
+type Identifier struct {
+	XMLName               xml.Name `xml:"FeesEstimateIdentifier"`
+	MarketplaceID         string   `xml:"MarketplaceId"`
+	IDType                string   `xml:"IdType"`
+	IDValue               string   `xml:"IdValue"`
+	SellerID              string   `xml:"SellerId"`
+	SellerInputIdentifier string   `xml:"SellerInputIdentifier"`
+	IsAmazonFulfilled     bool     `xml:"IsAmazonFulfilled"`
+	ListingPrice          Money    `xml:"PriceToEstimateFees>ListingPrice"`
+	ShippingPrice         Money    `xml:"PriceToEstimateFees>Shipping"`
+	Points                int      `xml:"PriceToEstimateFees>Points>PointsNumber"`
+}
+
+// FeesEstimate is the total and the per-fee breakdown
+type FeesEstimate struct {
+	XMLName              xml.Name    `xml:"FeesEstimate"`
+	TimeOfFeesEstimation string      `xml:"TimeOfFeesEstimation"`
+	TotalFeesEstimate    Money       `xml:"TotalFeesEstimate"`
+	FeeDetails           []FeeDetail `xml:"FeeDetailList>FeeDetail"`
+}
+
+// FeeDetail is one fee. FinalFee is FeeAmount less FeePromotion;
+// FBAFees is itself broken down in IncludedFees.
+type FeeDetail struct {
+	XMLName      xml.Name    `xml:"FeeDetail"`
+	FeeType      string      `xml:"FeeType"`
+	FeeAmount    Money       `xml:"FeeAmount"`
+	FeePromotion Money       `xml:"FeePromotion"`
+	TaxAmount    Money       `xml:"TaxAmount"`
+	FinalFee     Money       `xml:"FinalFee"`
+	IncludedFees []FeeDetail `xml:"IncludedFeeDetailList>FeeDetail"`
+}
+
+// Money has currency and an amount
+type Money struct {
+	CurrencyCode string `xml:"CurrencyCode"`
+	Amount       string `xml:"Amount"`
+}
+
+// Error is returned in place of FeesEstimate when Status is ClientError or ServerError
+type Error struct {
+	Type    string `xml:"Type"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// Total returns the TotalFeesEstimate
+func (r XMLResult) Total() Money {
+	return r.FeesEstimate.TotalFeesEstimate
+}
+
+// Fee returns the top level FeeDetail of feeType, e.g. ReferralFee
+func (r XMLResult) Fee(feeType string) (FeeDetail, bool) {
+	for _, f := range r.FeesEstimate.FeeDetails {
+		if f.FeeType == feeType {
+			return f, true
+		}
+	}
+	return FeeDetail{}, false
+}
+
+// ByIdentifier keys the results by SellerInputIdentifier
+func (r *XMLResponse) ByIdentifier() map[string]XMLResult {
+	m := make(map[string]XMLResult, len(r.Results))
+	for _, res := range r.Results {
+		m[res.Identifier.SellerInputIdentifier] = res
+	}
+	return m
+}
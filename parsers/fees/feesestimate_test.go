@@ -0,0 +1,56 @@
+package fees
+
+import "testing"
+
+const feesEstimateXML = `<?xml version="1.0"?>
+<GetMyFeesEstimateResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetMyFeesEstimateResult><FeesEstimateResultList>
+<FeesEstimateResult>
+<FeesEstimateIdentifier><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><IdType>ASIN</IdType><SellerId>A1IMEXAMPLEWRC</SellerId><SellerInputIdentifier>request-1</SellerInputIdentifier><IsAmazonFulfilled>true</IsAmazonFulfilled><IdValue>B002KT3XQM</IdValue>
+<PriceToEstimateFees><ListingPrice><CurrencyCode>USD</CurrencyCode><Amount>30.00</Amount></ListingPrice><Shipping><CurrencyCode>USD</CurrencyCode><Amount>3.99</Amount></Shipping><Points><PointsNumber>0</PointsNumber></Points></PriceToEstimateFees></FeesEstimateIdentifier>
+<FeesEstimate><TimeOfFeesEstimation>2015-07-19T23:15:11.859Z</TimeOfFeesEstimation><TotalFeesEstimate><CurrencyCode>USD</CurrencyCode><Amount>10.00</Amount></TotalFeesEstimate>
+<FeeDetailList>
+<FeeDetail><FeeType>ReferralFee</FeeType><FeeAmount><CurrencyCode>USD</CurrencyCode><Amount>5.10</Amount></FeeAmount><FeePromotion><CurrencyCode>USD</CurrencyCode><Amount>0.10</Amount></FeePromotion><FinalFee><CurrencyCode>USD</CurrencyCode><Amount>5.00</Amount></FinalFee></FeeDetail>
+<FeeDetail><FeeType>FBAFees</FeeType><FeeAmount><CurrencyCode>USD</CurrencyCode><Amount>5.00</Amount></FeeAmount><FinalFee><CurrencyCode>USD</CurrencyCode><Amount>5.00</Amount></FinalFee>
+<IncludedFeeDetailList><FeeDetail><FeeType>FBAWeightHandling</FeeType><FinalFee><CurrencyCode>USD</CurrencyCode><Amount>3.00</Amount></FinalFee></FeeDetail><FeeDetail><FeeType>FBAPickAndPack</FeeType><FinalFee><CurrencyCode>USD</CurrencyCode><Amount>2.00</Amount></FinalFee></FeeDetail></IncludedFeeDetailList></FeeDetail>
+</FeeDetailList></FeesEstimate>
+<Status>Success</Status></FeesEstimateResult>
+<FeesEstimateResult>
+<FeesEstimateIdentifier><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><IdType>SellerSKU</IdType><SellerInputIdentifier>request-2</SellerInputIdentifier><IdValue>SKU-404</IdValue></FeesEstimateIdentifier>
+<Status>ClientError</Status><Error><Type>Sender</Type><Code>InvalidParameterValue</Code><Message>There is no SKU SKU-404 in your catalog</Message></Error></FeesEstimateResult>
+</FeesEstimateResultList></GetMyFeesEstimateResult>
+<ResponseMetadata><RequestId>c9ba6b82-EXAMPLE</RequestId></ResponseMetadata></GetMyFeesEstimateResponse>`
+
+func TestDecode(t *testing.T) {
+	r, err := Decode([]byte(feesEstimateXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 2 || r.ResponseMetadata.RequestID != "c9ba6b82-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+
+	by := r.ByIdentifier()
+	ok, found := by["request-1"]
+	if !found || ok.Status != Success || ok.Total().Amount != "10.00" || ok.Total().CurrencyCode != "USD" {
+		t.Fatalf("request-1 = %+v", ok)
+	}
+	id := ok.Identifier
+	if id.IDValue != "B002KT3XQM" || !id.IsAmazonFulfilled || id.ListingPrice.Amount != "30.00" || id.ShippingPrice.Amount != "3.99" {
+		t.Fatalf("identifier = %+v", id)
+	}
+	if f, found := ok.Fee(ReferralFee); !found || f.FeeAmount.Amount != "5.10" || f.FeePromotion.Amount != "0.10" || f.FinalFee.Amount != "5.00" {
+		t.Fatalf("ReferralFee = %+v, %v", f, found)
+	}
+	if f, found := ok.Fee(FBAFees); !found || len(f.IncludedFees) != 2 || f.IncludedFees[0].FeeType != "FBAWeightHandling" || f.IncludedFees[1].FinalFee.Amount != "2.00" {
+		t.Fatalf("FBAFees = %+v, %v", f, found)
+	}
+	if _, found := ok.Fee(VariableClosingFee); found {
+		t.Fatal("found a VariableClosingFee that is not in the response")
+	}
+
+	bad := by["request-2"]
+	if bad.Status == Success || bad.Error.Code != "InvalidParameterValue" || len(bad.FeesEstimate.FeeDetails) != 0 {
+		t.Fatalf("request-2 = %+v", bad)
+	}
+}
@@ -0,0 +1,176 @@
+package lmp
+
+import (
+	"encoding/xml"
+	"sync"
+)
+
+// XMLParse extends Parser
+type XMLParse interface {
+	Parser(body []byte)
+}
+
+// XMLParser represents an XML parser.
+type XMLParser struct {
+	decoder  *xml.Decoder
+	decMutex *sync.Mutex
+	mapMutex *sync.Mutex
+}
+
+// NewXMLParser creates a new XML parser.
+func NewXMLParser() *XMLParser {
+	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
+}
+
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
+func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
+	var i XMLResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// XMLResponse contains the XML results of the func ListMatchingProducts()
+type XMLResponse struct {
+	XMLName          xml.Name         `xml:"ListMatchingProductsResponse"`
+	Results          XMLResult        `xml:"ListMatchingProductsResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// XMLResult is the xml container for ListMatchingProducts() Responses.
+// Amazon returns at most 10 Products, most relevant first.
+type XMLResult struct {
+	XMLName  xml.Name  `xml:"ListMatchingProductsResult"`
+	Products []Product `xml:"Products>Product"`
+}
+
+// ResponseMetadata returns a RequestID
+type ResponseMetadata struct {
+	XMLName   xml.Name `xml:"ResponseMetadata"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// Product is a catalog item as returned by ListMatchingProducts and
+// GetMatchingProductForId.
+type Product struct {
+	XMLName       xml.Name       `xml:"Product"`
+	Identifiers   Identifier     `xml:"Identifiers"`
+	AttributeSets AttributeSets  `xml:"AttributeSets"`
+	Relationships Relationships  `xml:"Relationships"`
+	SalesRankings []SalesRanking `xml:"SalesRankings>SalesRank"`
+}
+
+// ASIN returns the ASIN of p in its Marketplace
+func (p Product) ASIN() string {
+	return p.Identifiers.ASIN
+}
+
+// Identifier describes the ASIN & MarketplaceId of a Product
+type Identifier struct {
+	XMLName       xml.Name `xml:"Identifiers"`
+	MarketplaceID string   `xml:"MarketplaceASIN>MarketplaceId"`
+	ASIN          string   `xml:"MarketplaceASIN>ASIN"`
+}
+
+// AttributeSets holds one ItemAttributes per language.
+// The elements are in the ns2 namespace, which is matched by local name.
+type AttributeSets struct {
+	XMLName        xml.Name         `xml:"AttributeSets"`
+	ItemAttributes []ItemAttributes `xml:"ItemAttributes"`
+}
+
+// ItemAttributes describes a Product in one language
+type ItemAttributes struct {
+	XMLName         xml.Name `xml:"ItemAttributes"`
+	Lang            string   `xml:"lang,attr"`
+	Title           string   `xml:"Title"`
+	Brand           string   `xml:"Brand"`
+	Manufacturer    string   `xml:"Manufacturer"`
+	Model           string   `xml:"Model"`
+	PartNumber      string   `xml:"PartNumber"`
+	Binding         string   `xml:"Binding"`
+	Color           string   `xml:"Color"`
+	Size            string   `xml:"Size"`
+	ProductGroup    string   `xml:"ProductGroup"`
+	ProductTypeName string   `xml:"ProductTypeName"`
+	Feature         []string `xml:"Feature"`
+	ListPrice       Price    `xml:"ListPrice"`
+
+	ItemDimensions    Dimensions `xml:"ItemDimensions"`
+	PackageDimensions Dimensions `xml:"PackageDimensions"`
+	PackageQuantity   int        `xml:"PackageQuantity"`
+	NumberOfItems     int        `xml:"NumberOfItems"`
+	SmallImage        Image      `xml:"SmallImage"`
+}
+
+// Dimensions of an item or its package. Each value carries its own Units,
+// e.g. inches or pounds.
+type Dimensions struct {
+	Height Measure `xml:"Height"`
+	Length Measure `xml:"Length"`
+	Width  Measure `xml:"Width"`
+	Weight Measure `xml:"Weight"`
+}
+
+// Measure is a decimal value and its units
+type Measure struct {
+	Value string `xml:",chardata"`
+	Units string `xml:"Units,attr"`
+}
+
+// Image is the URL and size of a product image.
+// Amazon only returns the small image; the URL can be resized by
+// replacing the _SL75_ size suffix.
+type Image struct {
+	URL    string  `xml:"URL"`
+	Height Measure `xml:"Height"`
+	Width  Measure `xml:"Width"`
+}
+
+// Price has currency and an amount
+type Price struct {
+	Amount       string `xml:"Amount"`
+	CurrencyCode string `xml:"CurrencyCode"`
+}
+
+// Relationships links a variation child to its parent, or a parent to its children.
+type Relationships struct {
+	XMLName         xml.Name          `xml:"Relationships"`
+	VariationParent []VariationParent `xml:"VariationParent"`
+	VariationChild  []VariationChild  `xml:"VariationChild"`
+}
+
+// VariationParent identifies the parent of a variation child
+type VariationParent struct {
+	XMLName     xml.Name   `xml:"VariationParent"`
+	Identifiers Identifier `xml:"Identifiers"`
+}
+
+// VariationChild identifies a child of a variation parent and the
+// attributes it varies by
+type VariationChild struct {
+	XMLName     xml.Name   `xml:"VariationChild"`
+	Identifiers Identifier `xml:"Identifiers"`
+	Color       string     `xml:"Color"`
+	Size        string     `xml:"Size"`
+	Edition     string     `xml:"Edition"`
+	Flavor      string     `xml:"Flavor"`
+	Style       string     `xml:"Style"`
+}
+
+// SalesRanking is the rank of a Product in one category.
+// ProductCategoryId is either a browse node or a product group such as book_display_on_website.
+type SalesRanking struct {
+	XMLName           xml.Name `xml:"SalesRank"`
+	ProductCategoryID string   `xml:"ProductCategoryId"`
+	Rank              int      `xml:"Rank"`
+}
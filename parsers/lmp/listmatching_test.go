@@ -0,0 +1,35 @@
+package lmp
+
+import "testing"
+
+const listMatchingXML = `<?xml version="1.0"?>
+<ListMatchingProductsResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<ListMatchingProductsResult><Products xmlns:ns2="http://mws.amazonservices.com/schema/Products/2011-10-01/default.xsd">
+<Product><Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B002KT3XRQ</ASIN></MarketplaceASIN></Identifiers>
+<AttributeSets><ns2:ItemAttributes xml:lang="en-US"><ns2:Binding>Apparel</ns2:Binding><ns2:Brand>Ataja</ns2:Brand><ns2:Feature>Pleated</ns2:Feature><ns2:Feature>Cotton</ns2:Feature><ns2:ListPrice><ns2:Amount>9.99</ns2:Amount><ns2:CurrencyCode>USD</ns2:CurrencyCode></ns2:ListPrice><ns2:Title>Ataja Shirt</ns2:Title></ns2:ItemAttributes></AttributeSets>
+<Relationships><VariationChild><Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B002KT3XQC</ASIN></MarketplaceASIN></Identifiers><ns2:Color>Black</ns2:Color><ns2:Size>Small</ns2:Size></VariationChild></Relationships>
+<SalesRankings><SalesRank><ProductCategoryId>apparel_display_on_website</ProductCategoryId><Rank>481</Rank></SalesRank></SalesRankings>
+</Product></Products></ListMatchingProductsResult>
+<ResponseMetadata><RequestId>3d2b1ea5-EXAMPLE</RequestId></ResponseMetadata></ListMatchingProductsResponse>`
+
+func TestDecode(t *testing.T) {
+	r, err := Decode([]byte(listMatchingXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results.Products) != 1 || r.ResponseMetadata.RequestID != "3d2b1ea5-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+	p := r.Results.Products[0]
+	a := p.AttributeSets.ItemAttributes[0]
+	if p.ASIN() != "B002KT3XRQ" || a.Lang != "en-US" || a.Brand != "Ataja" || len(a.Feature) != 2 || a.ListPrice.Amount != "9.99" {
+		t.Fatalf("attributes = %+v", a)
+	}
+	child := p.Relationships.VariationChild[0]
+	if child.Identifiers.ASIN != "B002KT3XQC" || child.Color != "Black" || child.Size != "Small" {
+		t.Fatalf("variation child = %+v", child)
+	}
+	if p.SalesRankings[0].ProductCategoryID != "apparel_display_on_website" || p.SalesRankings[0].Rank != 481 {
+		t.Fatalf("sales rankings = %+v", p.SalesRankings)
+	}
+}
@@ -2,7 +2,6 @@ package low
 
 import (
 	"encoding/xml"
-	"log"
 	"sync"
 	"time"
 )
@@ -24,11 +23,19 @@ func NewXMLParser() *XMLParser {
 	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
 }
 
-// Parser parses the xml response for MWS Products operations
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
 func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
 	var i XMLResponse
 	if err := xml.Unmarshal(body, &i); err != nil {
-		log.Println(err)
+		return &i, err
 	}
 	i.tooSoon()
 	for _, r := range i.Results {
@@ -36,7 +43,7 @@ func (p *XMLParser) Parser(body []byte) *XMLResponse {
 			i.parseTime()
 		}
 	}
-	return &i
+	return &i, nil
 }
 func (r *XMLResponse) tooSoon() {
 	for _, p := range r.Results {
@@ -2,7 +2,6 @@ package lowp
 
 import (
 	"encoding/xml"
-	"log"
 	"sync"
 	"time"
 )
@@ -36,11 +35,19 @@ func NewXMLParser() *XMLParser {
 	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
 }
 
-// Parser parses the xml response for MWS Products operations
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
 func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
 	var i XMLResponse
 	if err := xml.Unmarshal(body, &i); err != nil {
-		log.Println(err)
+		return &i, err
 	}
 	i.tooSoon()
 	for _, r := range i.Results {
@@ -48,7 +55,7 @@ func (p *XMLParser) Parser(body []byte) *XMLResponse {
 			i.parseTime()
 		}
 	}
-	return &i
+	return &i, nil
 }
 func (r *XMLResponse) tooSoon() {
 	for _, p := range r.Results {
@@ -90,13 +97,31 @@ type XMLResponse struct {
 	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
 }
 
-// XMLResult is the xml container for GetMyPriceForSKU() Responses
+// ASINResponse contains the XML results of the func GetLowestPricedOffersForASIN
+type ASINResponse struct {
+	XMLName          xml.Name         `xml:"GetLowestPricedOffersForASINResponse"`
+	Results          []XMLResult      `xml:"GetLowestPricedOffersForASINResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// DecodeASIN is Decode for GetLowestPricedOffersForASIN, whose results share XMLResult.
+func DecodeASIN(body []byte) (*ASINResponse, error) {
+	var i ASINResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// XMLResult is the xml container for GetLowestPricedOffersForSKU() and
+// GetLowestPricedOffersForASIN() Responses. SKU is set for the former, ASIN for the latter.
 type XMLResult struct {
-	XMLName       xml.Name `xml:"GetLowestPricedOffersForSKUResult"`
-	MarketplaceID string   `xml:"MarketplaceID,attr"`
-	SKU           string   `xml:"SKU,attr"`
-	ItemCondition string   `xml:"ItemCondition,attr"`
-	Status        string   `xml:"status,attr"`
+	XMLName       xml.Name
+	MarketplaceID string `xml:"MarketplaceID,attr"`
+	SKU           string `xml:"SKU,attr"`
+	ASIN          string `xml:"ASIN,attr"`
+	ItemCondition string `xml:"ItemCondition,attr"`
+	Status        string `xml:"status,attr"`
 	Statusbool    bool
 	TooSoon       bool
 	Product       Product
@@ -115,6 +140,7 @@ type Identifier struct {
 	XMLName           xml.Name `xml:"Identifier"`
 	MarketplaceID     string   `xml:"MarketplaceID"`
 	SellerSKU         string   `xml:"SellerSKU"`
+	ASIN              string   `xml:"ASIN"`
 	ItemCondition     string   `xml:"ItemCondition"`
 	TimeOfOfferChange string   `xml:"TimeOfOfferChange"`
 	ParsedTime        time.Time
@@ -0,0 +1,29 @@
+package lowp
+
+import "testing"
+
+func TestDecodeASIN(t *testing.T) {
+	r, err := DecodeASIN([]byte(`<?xml version="1.0"?>
+<GetLowestPricedOffersForASINResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetLowestPricedOffersForASINResult MarketplaceID="ATVPDKIKX0DER" ASIN="B00V5DG6IQ" ItemCondition="New" status="Success">
+<Identifier><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B00V5DG6IQ</ASIN><ItemCondition>New</ItemCondition><TimeOfOfferChange>2015-07-19T23:15:11.859Z</TimeOfOfferChange></Identifier>
+<Summary><TotalOfferCount>4</TotalOfferCount></Summary>
+</GetLowestPricedOffersForASINResult>
+<ResponseMetadata><RequestId>a8b7b5fa-EXAMPLE</RequestId></ResponseMetadata></GetLowestPricedOffersForASINResponse>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 1 || r.ResponseMetadata.RequestID != "a8b7b5fa-EXAMPLE" {
+		t.Fatalf("%+v", r)
+	}
+	res := r.Results[0]
+	if res.ASIN != "B00V5DG6IQ" || res.SKU != "" || res.MarketplaceID != "ATVPDKIKX0DER" || res.ItemCondition != "New" || res.Status != "Success" {
+		t.Fatalf("result = %+v", res)
+	}
+
+	// The SKU response shares XMLResult.
+	s, err := Decode([]byte(`<GetLowestPricedOffersForSKUResponse><GetLowestPricedOffersForSKUResult SKU="SKU-1" ItemCondition="Used" status="Success"/></GetLowestPricedOffersForSKUResponse>`))
+	if err != nil || len(s.Results) != 1 || s.Results[0].SKU != "SKU-1" || s.Results[0].ASIN != "" {
+		t.Fatalf("Decode = %+v, %v", s, err)
+	}
+}
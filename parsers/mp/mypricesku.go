@@ -2,7 +2,6 @@ package mp
 
 import (
 	"encoding/xml"
-	"log"
 	"sync"
 	"time"
 )
@@ -24,11 +23,19 @@ func NewXMLParser() *XMLParser {
 	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
 }
 
-// Parser parses the xml response for MWS Products operations
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
 func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
 	var i XMLResponse
 	if err := xml.Unmarshal(body, &i); err != nil {
-		log.Fatal(err)
+		return &i, err
 	}
 	i.tooSoon()
 	for _, r := range i.Results {
@@ -36,7 +43,7 @@ func (p *XMLParser) Parser(body []byte) *XMLResponse {
 			i.parseTime()
 		}
 	}
-	return &i
+	return &i, nil
 }
 func (r *XMLResponse) tooSoon() {
 	for _, p := range r.Results {
@@ -60,10 +67,26 @@ type XMLResponse struct {
 	Results []XMLResult `xml:"GetMyPriceForSKUResult"`
 }
 
-// XMLResult is the xml container for GetMyPriceForSKU() Responses
+// ASINResponse contains the XML results of the func GetMyPriceForASIN()
+type ASINResponse struct {
+	XMLName xml.Name    `xml:"GetMyPriceForASINResponse"`
+	Results []XMLResult `xml:"GetMyPriceForASINResult"`
+}
+
+// DecodeASIN is Decode for GetMyPriceForASIN, whose results share XMLResult.
+func DecodeASIN(body []byte) (*ASINResponse, error) {
+	var i ASINResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// XMLResult is the xml container for GetMyPriceForSKU() and GetMyPriceForASIN() Responses.
+// SellerSKU is set for the former, ASIN for the latter.
 type XMLResult struct {
-	XMLName xml.Name `xml:"GetMyPriceForSKUResult"`
-	// ASIN    string   `xml:"SellerSKU,attr"`
+	XMLName   xml.Name
+	ASIN      string `xml:"ASIN,attr"`
 	SellerSKU string `xml:"SellerSKU,attr"`
 	Status    string `xml:"status,attr"`
 	TooSoon   bool
@@ -0,0 +1,36 @@
+package mp
+
+import "testing"
+
+func TestDecodeASIN(t *testing.T) {
+	r, err := DecodeASIN([]byte(`<?xml version="1.0"?>
+<GetMyPriceForASINResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetMyPriceForASINResult ASIN="B00V5DG6IQ" status="Success"><Product>
+<Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B00V5DG6IQ</ASIN></MarketplaceASIN></Identifiers>
+<Offers><Offer><RegularPrice><CurrencyCode>USD</CurrencyCode><Amount>5.00</Amount></RegularPrice><FulfillmentChannel>MERCHANT</FulfillmentChannel><ItemCondition>New</ItemCondition><ItemSubCondition>New</ItemSubCondition></Offer></Offers>
+</Product></GetMyPriceForASINResult>
+<GetMyPriceForASINResult ASIN="B00XXXXXXX" status="ClientError"/>
+</GetMyPriceForASINResponse>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 2 {
+		t.Fatalf("%d results, want 2", len(r.Results))
+	}
+	res := r.Results[0]
+	if res.ASIN != "B00V5DG6IQ" || res.SellerSKU != "" || res.Status != "Success" || res.Product.Identifiers.ASIN != "B00V5DG6IQ" {
+		t.Fatalf("result = %+v", res)
+	}
+	if o := res.Product.Offers; len(o) != 1 || o[0].RegularPrice != "5.00" || o[0].CurrencyCode != "USD" || o[0].FulfillmentChannel != "MERCHANT" {
+		t.Fatalf("offers = %+v", o)
+	}
+	if r.Results[1].Status != "ClientError" {
+		t.Fatalf("result = %+v", r.Results[1])
+	}
+
+	// The SKU response shares XMLResult.
+	s, err := Decode([]byte(`<GetMyPriceForSKUResponse><GetMyPriceForSKUResult SellerSKU="SKU-1" status="Success"><Product/></GetMyPriceForSKUResult></GetMyPriceForSKUResponse>`))
+	if err != nil || len(s.Results) != 1 || s.Results[0].SellerSKU != "SKU-1" || s.Results[0].ASIN != "" {
+		t.Fatalf("Decode = %+v, %v", s, err)
+	}
+}
@@ -0,0 +1,104 @@
+package mpfid
+
+import (
+	"encoding/xml"
+	"sync"
+
+	"github.com/rdorrigan/mws/parsers/lmp"
+)
+
+// XMLParse extends Parser
+type XMLParse interface {
+	Parser(body []byte)
+}
+
+// XMLParser represents an XML parser.
+type XMLParser struct {
+	decoder  *xml.Decoder
+	decMutex *sync.Mutex
+	mapMutex *sync.Mutex
+}
+
+// NewXMLParser creates a new XML parser.
+func NewXMLParser() *XMLParser {
+	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
+}
+
+// Parser parses the xml response for MWS Products operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
+func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
+	var i XMLResponse
+	if err := xml.Unmarshal(body, &i); err != nil {
+		return &i, err
+	}
+	return &i, nil
+}
+
+// Success is the status of an Id that matched at least one product
+const Success = "Success"
+
+// XMLResponse contains the XML results of the func GetMatchingProductForID()
+type XMLResponse struct {
+	XMLName          xml.Name         `xml:"GetMatchingProductForIdResponse"`
+	Results          []XMLResult      `xml:"GetMatchingProductForIdResult"`
+	ResponseMetadata ResponseMetadata `xml:"ResponseMetadata"`
+}
+
+// XMLResult is the xml container for one Id of GetMatchingProductForID().
+// An Id may match several products, e.g. a UPC shared by variations.
+type XMLResult struct {
+	XMLName  xml.Name      `xml:"GetMatchingProductForIdResult"`
+	ID       string        `xml:"Id,attr"`
+	IDType   string        `xml:"IdType,attr"`
+	Status   string        `xml:"status,attr"`
+	Products []lmp.Product `xml:"Products>Product"`
+	Error    Error         `xml:"Error"`
+}
+
+// ResponseMetadata returns a RequestID
+type ResponseMetadata struct {
+	XMLName   xml.Name `xml:"ResponseMetadata"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// Error is returned in place of Products when an Id did not match,
+// e.g. Code InvalidParameterValue for an unknown UPC.
+type Error struct {
+	Type    string `xml:"Type"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// OK reports whether the Id matched
+func (r XMLResult) OK() bool {
+	return r.Status == Success
+}
+
+// ASINs returns the ASIN of every product matching the Id
+func (r XMLResult) ASINs() []string {
+	asins := make([]string, 0, len(r.Products))
+	for _, p := range r.Products {
+		asins = append(asins, p.ASIN())
+	}
+	return asins
+}
+
+// ASINMap maps each matched Id to the ASIN of its first product.
+// Ids that failed or matched nothing are left out.
+func (r *XMLResponse) ASINMap() map[string]string {
+	m := make(map[string]string)
+	for _, res := range r.Results {
+		if !res.OK() || len(res.Products) == 0 {
+			continue
+		}
+		m[res.ID] = res.Products[0].ASIN()
+	}
+	return m
+}
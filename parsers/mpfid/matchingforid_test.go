@@ -0,0 +1,51 @@
+package mpfid
+
+import "testing"
+
+const matchingForIDXML = `<?xml version="1.0"?>
+<GetMatchingProductForIdResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01">
+<GetMatchingProductForIdResult Id="082676082658" IdType="UPC" status="Success"><Products xmlns:ns2="http://mws.amazonservices.com/schema/Products/2011-10-01/default.xsd">
+<Product><Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B000LP9A1Q</ASIN></MarketplaceASIN></Identifiers>
+<AttributeSets><ns2:ItemAttributes xml:lang="en-US"><ns2:Brand>Acme</ns2:Brand><ns2:ItemDimensions><ns2:Height Units="inches">1.50</ns2:Height><ns2:Weight Units="pounds">0.20</ns2:Weight></ns2:ItemDimensions><ns2:PackageQuantity>2</ns2:PackageQuantity><ns2:SmallImage><ns2:URL>http://ecx.images-amazon.com/images/I/41_SL75_.jpg</ns2:URL><ns2:Height Units="pixels">75</ns2:Height></ns2:SmallImage><ns2:Title>Widget</ns2:Title></ns2:ItemAttributes></AttributeSets>
+<Relationships><ns2:VariationParent><Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B000PARENT</ASIN></MarketplaceASIN></Identifiers></ns2:VariationParent></Relationships>
+<SalesRankings><SalesRank><ProductCategoryId>toy_display_on_website</ProductCategoryId><Rank>1</Rank></SalesRank></SalesRankings></Product>
+<Product><Identifiers><MarketplaceASIN><MarketplaceId>ATVPDKIKX0DER</MarketplaceId><ASIN>B000SECOND</ASIN></MarketplaceASIN></Identifiers></Product>
+</Products></GetMatchingProductForIdResult>
+<GetMatchingProductForIdResult Id="1234" IdType="UPC" status="ClientError"><Error><Type>Sender</Type><Code>InvalidParameterValue</Code><Message>Invalid UPC identifier 1234 for marketplace ATVPDKIKX0DER</Message></Error></GetMatchingProductForIdResult>
+<ResponseMetadata><RequestId>efeab958-EXAMPLE</RequestId></ResponseMetadata></GetMatchingProductForIdResponse>`
+
+func TestDecode(t *testing.T) {
+	r, err := Decode([]byte(matchingForIDXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Results) != 2 {
+		t.Fatalf("%d results, want 2", len(r.Results))
+	}
+
+	ok := r.Results[0]
+	if !ok.OK() || ok.ID != "082676082658" || ok.IDType != "UPC" {
+		t.Fatalf("result = %+v", ok)
+	}
+	if asins := ok.ASINs(); len(asins) != 2 || asins[1] != "B000SECOND" {
+		t.Fatalf("ASINs = %v", asins)
+	}
+	a := ok.Products[0].AttributeSets.ItemAttributes[0]
+	if a.Lang != "en-US" || a.ItemDimensions.Height.Value != "1.50" || a.ItemDimensions.Weight.Units != "pounds" ||
+		a.PackageQuantity != 2 || a.SmallImage.Height.Value != "75" {
+		t.Fatalf("attributes = %+v", a)
+	}
+	if parent := ok.Products[0].Relationships.VariationParent; len(parent) != 1 || parent[0].Identifiers.ASIN != "B000PARENT" {
+		t.Fatalf("variation parent = %+v", parent)
+	}
+
+	bad := r.Results[1]
+	if bad.OK() || bad.Error.Code != "InvalidParameterValue" || len(bad.Products) != 0 {
+		t.Fatalf("failed result = %+v", bad)
+	}
+
+	m := r.ASINMap()
+	if len(m) != 1 || m["082676082658"] != "B000LP9A1Q" {
+		t.Fatalf("ASINMap = %v", m)
+	}
+}
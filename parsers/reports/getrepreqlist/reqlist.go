@@ -2,7 +2,6 @@ package getrepreqlist
 
 import (
 	"encoding/xml"
-	"log"
 	"sync"
 )
 
@@ -23,13 +22,21 @@ func NewXMLParser() *XMLParser {
 	return &XMLParser{nil, &sync.Mutex{}, &sync.Mutex{}}
 }
 
-// Parser parses the xml response for MWS ReportRequestInfos operations
+// Parser parses the xml response for MWS ReportRequestInfos operations.
+// Malformed XML yields whatever was decoded before the error;
+// use Decode to get the error.
 func (p *XMLParser) Parser(body []byte) *XMLResponse {
+	i, _ := Decode(body)
+	return i
+}
+
+// Decode is Parser that also returns the unmarshal error.
+func Decode(body []byte) (*XMLResponse, error) {
 	var i XMLResponse
 	if err := xml.Unmarshal(body, &i); err != nil {
-		log.Fatal(err)
+		return &i, err
 	}
-	return &i
+	return &i, nil
 }
 
 // XMLResponse contains the XML results of the func GetMyPriceForSKU()
@@ -0,0 +1,151 @@
+package amazonmws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PoolOperation is an operation a WorkerPool runs for each identifier.
+// Do returns the parsed result for that identifier alone.
+type PoolOperation struct {
+	Name string
+	Do   func(ctx context.Context, api MWSAPI, id string) (interface{}, error)
+}
+
+// PoolOperations for the pricing lookups. Each Result holds the per-item
+// result type of the matching Parsed operation.
+var (
+	MyPriceForSKU = PoolOperation{"GetMyPriceForSKU", func(ctx context.Context, api MWSAPI, id string) (interface{}, error) {
+		resp, err := api.GetMyPriceForSKUParsed(ctx, []string{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Results) == 0 {
+			return nil, noResult(id)
+		}
+		return resp.Results[0], nil
+	}}
+	LowestOfferListingsForSKU = PoolOperation{"GetLowestOfferListingsForSKU", func(ctx context.Context, api MWSAPI, id string) (interface{}, error) {
+		resp, err := api.GetLowestOfferListingsForSKUParsed(ctx, []string{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Results) == 0 {
+			return nil, noResult(id)
+		}
+		return resp.Results[0], nil
+	}}
+	LowestPricedOffersForSKU = PoolOperation{"GetLowestPricedOffersForSKU", func(ctx context.Context, api MWSAPI, id string) (interface{}, error) {
+		resp, err := api.GetLowestPricedOffersForSKUParsed(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Results) == 0 {
+			return nil, noResult(id)
+		}
+		return resp.Results[0], nil
+	}}
+	LowestOfferListingsForASIN = PoolOperation{"GetLowestOfferListingsForASIN", func(ctx context.Context, api MWSAPI, id string) (interface{}, error) {
+		doc, err := api.GetLowestOfferListingsForASINParsed(ctx, []string{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Results) == 0 {
+			return nil, noResult(id)
+		}
+		return doc.Results[0], nil
+	}}
+)
+
+// noResult is the error a PoolOperation returns when MWS answers without
+// a result for id.
+func noResult(id string) error {
+	return fmt.Errorf("amazonmws: no result for %q", id)
+}
+
+// ItemResult is the outcome of one PoolOperation for one identifier.
+// Index is the identifier's position in the input stream.
+type ItemResult struct {
+	Index     int
+	ID        string
+	Operation string
+	Result    interface{}
+	Err       error
+}
+
+// WorkerPool looks up a stream of SKUs or ASINs with a bounded number of
+// goroutines. All workers share the client, and so its RateLimiter.
+type WorkerPool struct {
+	api     MWSAPI
+	workers int
+	ops     []PoolOperation
+}
+
+// NewWorkerPool returns a WorkerPool running ops for every identifier
+// on at most workers goroutines.
+func NewWorkerPool(api MWSAPI, workers int, ops ...PoolOperation) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	return &WorkerPool{api: api, workers: workers, ops: ops}
+}
+
+type poolJob struct {
+	index int
+	id    string
+}
+
+// Run reads identifiers from ids until it is closed or ctx is done and
+// sends one ItemResult per identifier and operation, in completion order.
+// The returned channel is closed once every worker has finished.
+func (p *WorkerPool) Run(ctx context.Context, ids <-chan string) <-chan ItemResult {
+	jobs := make(chan poolJob)
+	out := make(chan ItemResult)
+
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var id string
+			select {
+			case next, ok := <-ids:
+				if !ok {
+					return
+				}
+				id = next
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- poolJob{index, id}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(p.workers)
+	for i := 0; i < p.workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				for _, op := range p.ops {
+					res, err := op.Do(ctx, p.api, job.id)
+					select {
+					case out <- ItemResult{job.index, job.id, op.Name, res, err}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,68 @@
+package amazonmws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var echoOperation = PoolOperation{"Echo", func(ctx context.Context, api MWSAPI, id string) (interface{}, error) {
+	return id, nil
+}}
+
+func TestWorkerPoolRun(t *testing.T) {
+	ids := make(chan string)
+	go func() {
+		defer close(ids)
+		for _, id := range []string{"a", "b", "c"} {
+			ids <- id
+		}
+	}()
+
+	seen := make(map[string]int)
+	for r := range NewWorkerPool(MWSAPI{}, 2, echoOperation).Run(context.Background(), ids) {
+		if r.Err != nil || r.Result != r.ID || r.Operation != "Echo" {
+			t.Fatalf("unexpected %+v", r)
+		}
+		seen[r.ID] = r.Index
+	}
+	if len(seen) != 3 || seen["a"] != 0 || seen["c"] != 2 {
+		t.Fatalf("results by id = %v", seen)
+	}
+}
+
+func TestWorkerPoolCancelWithOpenInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := make(chan string, 1)
+	ids <- "a"
+	out := NewWorkerPool(MWSAPI{}, 2, echoOperation).Run(ctx, ids)
+
+	if r := <-out; r.ID != "a" {
+		t.Fatalf("first result = %+v", r)
+	}
+	cancel()
+
+	// ids is never closed; cancelling alone must close out.
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("out still open a second after cancel")
+		}
+	}
+}
+
+func TestPoolOperationNoResult(t *testing.T) {
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<GetMyPriceForSKUResponse xmlns="http://mws.amazonservices.com/schema/Products/2011-10-01"/>`))
+	})
+	res, err := MyPriceForSKU.Do(context.Background(), *api, "sku-1")
+	if res != nil || err == nil || err.Error() != `amazonmws: no result for "sku-1"` {
+		t.Fatalf("Do = %v, %v", res, err)
+	}
+}
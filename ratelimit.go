@@ -0,0 +1,344 @@
+package amazonmws
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Quota is the documented throttling for one MWS operation.
+// When PerItem is set the burst and restore rate count items rather than
+// requests, so a request for 20 ASINs costs 20 tokens. The hourly quota
+// always counts requests.
+type Quota struct {
+	MaxRequests int     /*maximum request quota, the burst size*/
+	RestoreRate float64 /*requests or items restored per second*/
+	Hourly      int     /*hourly request quota, 0 for none*/
+	PerItem     bool
+}
+
+// Quotas holds the documented quota of each operation, taken from the
+// MWS throttling tables reproduced at the bottom of throttle.go.
+var Quotas = map[string]Quota{
+	"ListMatchingProducts":          {20, 1.0 / 5, 720, false},
+	"GetMatchingProduct":            {20, 2, 7200, true},
+	"GetMatchingProductForId":       {20, 5, 18000, true},
+	"GetCompetitivePricingForSKU":   {20, 10, 36000, true},
+	"GetCompetitivePricingForASIN":  {20, 10, 36000, true},
+	"GetLowestOfferListingsForSKU":  {20, 10, 36000, true},
+	"GetLowestOfferListingsForASIN": {20, 10, 36000, true},
+	"GetLowestPricedOffersForSKU":   {10, 5, 200, true},
+	"GetLowestPricedOffersForASIN":  {10, 5, 200, true},
+	"GetMyFeesEstimate":             {20, 10, 36000, true},
+	"GetMyPriceForSKU":              {20, 10, 36000, true},
+	"GetMyPriceForASIN":             {20, 10, 36000, true},
+	"GetProductCategoriesForSKU":    {20, 1.0 / 5, 720, false},
+	"GetProductCategoriesForASIN":   {20, 1.0 / 5, 720, false},
+	"RequestReport":                 {15, 1.0 / 60, 60, false},
+	"GetReportRequestList":          {10, 1.0 / 45, 80, false},
+	"GetReport":                     {15, 1.0 / 60, 60, false},
+}
+
+// itemListPrefixes are the numbered list parameters whose entries count
+// as items for per-item throttling.
+var itemListPrefixes = []string{
+	"ASINList.ASIN.",
+	"SellerSKUList.SellerSKU.",
+	"IdList.Id.",
+}
+
+// sharedQuota maps operations that MWS throttles together onto the
+// operation whose bucket they draw from.
+var sharedQuota = map[string]string{
+	"GetCompetitivePricingForASIN":  "GetCompetitivePricingForSKU",
+	"GetLowestOfferListingsForASIN": "GetLowestOfferListingsForSKU",
+	"GetLowestPricedOffersForASIN":  "GetLowestPricedOffersForSKU",
+	"GetMyPriceForASIN":             "GetMyPriceForSKU",
+	"GetProductCategoriesForASIN":   "GetProductCategoriesForSKU",
+}
+
+// QuotaStatus is the hourly quota MWS reports in the x-mws-quota-max,
+// x-mws-quota-remaining and x-mws-quota-resetsOn response headers.
+type QuotaStatus struct {
+	Max       float64
+	Remaining float64
+	ResetsOn  time.Time
+}
+
+// parseQuotaHeaders reads the x-mws-quota-* headers from h.
+// ok is false when MWS did not send them.
+func parseQuotaHeaders(h http.Header) (q QuotaStatus, ok bool) {
+	max, err := strconv.ParseFloat(h.Get("x-mws-quota-max"), 64)
+	if err != nil {
+		return q, false
+	}
+	remaining, err := strconv.ParseFloat(h.Get("x-mws-quota-remaining"), 64)
+	if err != nil {
+		return q, false
+	}
+	resets, err := time.Parse(time.RFC3339, h.Get("x-mws-quota-resetsOn"))
+	if err != nil {
+		return q, false
+	}
+	return QuotaStatus{Max: max, Remaining: remaining, ResetsOn: resets}, true
+}
+
+// WithQuotaObserver calls fn with the QuotaStatus of every response
+// that carries the x-mws-quota-* headers.
+func WithQuotaObserver(fn func(operation string, q QuotaStatus)) Option {
+	return func(api *MWSAPI) error {
+		api.quotaObserver = fn
+		return nil
+	}
+}
+
+// QuotaStatus returns the last QuotaStatus MWS reported for operation,
+// as recorded by the client's RateLimiter.
+func (api MWSAPI) QuotaStatus(operation string) (QuotaStatus, bool) {
+	l := api.rateLimiter()
+	if l == nil {
+		return QuotaStatus{}, false
+	}
+	return l.QuotaStatus(operation)
+}
+
+// sellerLimiters are the RateLimiters of clients built as MWSAPI literals
+// rather than by NewMWSAPI, one per SellerID, so that literal clients of
+// the same seller share their throttling like Amazon does.
+var sellerLimiters = struct {
+	sync.Mutex
+	m map[string]*RateLimiter
+}{m: make(map[string]*RateLimiter)}
+
+// rateLimiter returns the client's RateLimiter, falling back to the shared
+// limiter of its SellerID when it has none, or nil after WithRateLimiter(nil).
+func (api MWSAPI) rateLimiter() *RateLimiter {
+	if api.limiter != nil || api.unlimited {
+		return api.limiter
+	}
+	sellerLimiters.Lock()
+	defer sellerLimiters.Unlock()
+	l, ok := sellerLimiters.m[api.SellerID]
+	if !ok {
+		l = NewRateLimiter(nil)
+		sellerLimiters.m[api.SellerID] = l
+	}
+	return l
+}
+
+// RateLimitMiddleware waits on l before every attempt, charging per-item
+// operations by the number of items in the Call, and feeds the
+// x-mws-quota-* headers of each Reply back into l.
+func RateLimitMiddleware(l *RateLimiter) Middleware {
+	return rateLimitMiddleware(l, systemClock{})
+}
+
+func rateLimitMiddleware(l *RateLimiter, clock Clock) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			start := clock.Now()
+			if err := l.WaitN(ctx, call.Operation, itemCount(call.Params)); err != nil {
+				return nil, err
+			}
+			call.ThrottleWait = clock.Now().Sub(start)
+
+			reply, err := next(ctx, call)
+			if reply != nil {
+				if q, ok := parseQuotaHeaders(reply.Header); ok {
+					l.Observe(call.Operation, q)
+				}
+			}
+			return reply, err
+		}
+	}
+}
+
+// RateLimiter is a token bucket per operation that also enforces the
+// hourly quota. It is safe for concurrent use; share one per seller.
+type RateLimiter struct {
+	mu      sync.Mutex
+	quotas  map[string]Quota
+	buckets map[string]*bucket
+	status  map[string]QuotaStatus
+	clock   Clock
+}
+
+type bucket struct {
+	quota     Quota
+	tokens    float64
+	last      time.Time
+	hourStart time.Time
+	hourUsed  int
+}
+
+// NewRateLimiter returns a RateLimiter for quotas, or for Quotas when
+// quotas is nil. Operations without a quota are never delayed.
+func NewRateLimiter(quotas map[string]Quota) *RateLimiter {
+	return NewRateLimiterWithClock(quotas, nil)
+}
+
+// NewRateLimiterWithClock is NewRateLimiter with its budgets and waits
+// timed by clock, e.g. a fake one in tests; nil is the system clock.
+// Use it for a limiter passed to WithRateLimiter, which WithClock does not reach.
+func NewRateLimiterWithClock(quotas map[string]Quota, clock Clock) *RateLimiter {
+	if quotas == nil {
+		quotas = Quotas
+	}
+	return &RateLimiter{
+		quotas:  quotas,
+		buckets: make(map[string]*bucket),
+		status:  make(map[string]QuotaStatus),
+		clock:   clockOrSystem(clock),
+	}
+}
+
+// WithRateLimiter replaces the client's RateLimiter; nil turns rate limiting off.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(api *MWSAPI) error {
+		api.limiter = l
+		api.unlimited = l == nil
+		return nil
+	}
+}
+
+// Wait blocks until operation may be sent, or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, operation string) error {
+	return l.WaitN(ctx, operation, 1)
+}
+
+// WaitN is Wait for a request carrying n items. Per-item operations take
+// n tokens, capped at the burst size; others take 1. Every request counts
+// once against the hourly quota.
+func (l *RateLimiter) WaitN(ctx context.Context, operation string, n int) error {
+	for {
+		wait, ok := l.reserve(operation, n)
+		if ok {
+			return nil
+		}
+		if err := sleepContext(ctx, l.clock, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes the tokens for n items of operation if they are available,
+// otherwise it returns how long to wait before trying again.
+func (l *RateLimiter) reserve(operation string, n int) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(operation)
+	if b == nil {
+		return 0, true
+	}
+	cost := b.cost(n)
+	now := l.clock.Now()
+	b.refill(now)
+
+	// The hourly quota counts requests, as do the x-mws-quota-* headers,
+	// so only the token bucket is charged per item.
+	if b.quota.Hourly > 0 && b.hourUsed+1 > b.quota.Hourly {
+		return b.hourStart.Add(time.Hour).Sub(now), false
+	}
+	if b.tokens < float64(cost) {
+		return time.Duration((float64(cost) - b.tokens) / b.quota.RestoreRate * float64(time.Second)), false
+	}
+	b.tokens -= float64(cost)
+	b.hourUsed++
+	return 0, true
+}
+
+func (b *bucket) cost(n int) int {
+	if !b.quota.PerItem || n < 1 {
+		return 1
+	}
+	if n > b.quota.MaxRequests {
+		return b.quota.MaxRequests
+	}
+	return n
+}
+
+// itemCount counts the entries of the numbered item lists in params,
+// with a single-item request counting as 1.
+func itemCount(params map[string]string) int {
+	n := 0
+	for k := range params {
+		// Each fees estimate request counts once, by its IdValue.
+		if strings.HasPrefix(k, feesEstimatePrefix) && strings.HasSuffix(k, ".IdValue") {
+			n++
+			continue
+		}
+		for _, prefix := range itemListPrefixes {
+			if strings.HasPrefix(k, prefix) {
+				n++
+				break
+			}
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// Observe corrects the hourly budget of operation with what MWS reported,
+// so the limiter follows Amazon's count rather than only its own.
+func (l *RateLimiter) Observe(operation string, q QuotaStatus) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.status[quotaKey(operation)] = q
+	b := l.bucket(operation)
+	if b == nil {
+		return
+	}
+	b.quota.Hourly = int(q.Max)
+	b.hourUsed = int(q.Max - q.Remaining)
+	b.hourStart = q.ResetsOn.Add(-time.Hour)
+}
+
+// QuotaStatus returns the last QuotaStatus passed to Observe for operation.
+func (l *RateLimiter) QuotaStatus(operation string) (QuotaStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	q, ok := l.status[quotaKey(operation)]
+	return q, ok
+}
+
+func quotaKey(operation string) string {
+	if shared, ok := sharedQuota[operation]; ok {
+		return shared
+	}
+	return operation
+}
+
+func (l *RateLimiter) bucket(operation string) *bucket {
+	operation = quotaKey(operation)
+	if b, ok := l.buckets[operation]; ok {
+		return b
+	}
+	q, ok := l.quotas[operation]
+	if !ok || q.RestoreRate <= 0 {
+		return nil
+	}
+	now := l.clock.Now()
+	b := &bucket{quota: q, tokens: float64(q.MaxRequests), last: now, hourStart: now}
+	l.buckets[operation] = b
+	return b
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.quota.RestoreRate
+	if max := float64(b.quota.MaxRequests); b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+	if !now.Before(b.hourStart.Add(time.Hour)) {
+		b.hourStart = now
+		b.hourUsed = 0
+	}
+}
@@ -0,0 +1,210 @@
+package amazonmws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when a timer is started: NewTimer advances it by d
+// and fires at once, so waits are instant and measurable.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.Advance(d)
+	t := fakeTimer(make(chan time.Time, 1))
+	t <- c.Now()
+	return t
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	return fakeTicker(make(chan time.Time))
+}
+
+type fakeTimer chan time.Time
+
+func (t fakeTimer) C() <-chan time.Time { return t }
+func (t fakeTimer) Stop() bool          { return true }
+
+type fakeTicker chan time.Time
+
+func (t fakeTicker) C() <-chan time.Time { return t }
+func (t fakeTicker) Stop()               {}
+
+func newTestLimiter(q Quota) (*RateLimiter, *fakeClock) {
+	clock := newFakeClock()
+	return NewRateLimiterWithClock(map[string]Quota{"Op": q}, clock), clock
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	l, _ := newTestLimiter(Quota{MaxRequests: 3, RestoreRate: 1})
+	for i := 0; i < 3; i++ {
+		if _, ok := l.reserve("Op", 1); !ok {
+			t.Fatalf("request %d of the burst was delayed", i+1)
+		}
+	}
+	wait, ok := l.reserve("Op", 1)
+	if ok || wait != time.Second {
+		t.Fatalf("reserve after burst = %v, %v; want 1s, false", wait, ok)
+	}
+}
+
+func TestRateLimiterRestore(t *testing.T) {
+	l, clock := newTestLimiter(Quota{MaxRequests: 3, RestoreRate: 1})
+	for i := 0; i < 3; i++ {
+		l.reserve("Op", 1)
+	}
+	clock.Advance(2 * time.Second)
+	for i := 0; i < 2; i++ {
+		if _, ok := l.reserve("Op", 1); !ok {
+			t.Fatalf("restored request %d was delayed", i+1)
+		}
+	}
+	if _, ok := l.reserve("Op", 1); ok {
+		t.Fatal("more requests restored than the restore rate allows")
+	}
+
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if _, ok := l.reserve("Op", 1); !ok {
+			t.Fatal("bucket refilled past the burst size")
+		}
+	}
+	if _, ok := l.reserve("Op", 1); ok {
+		t.Fatal("bucket refilled past the burst size")
+	}
+}
+
+func TestRateLimiterPerItem(t *testing.T) {
+	l, clock := newTestLimiter(Quota{MaxRequests: 20, RestoreRate: 10, PerItem: true})
+	ctx := context.Background()
+	start := clock.Now()
+	if err := l.WaitN(ctx, "Op", 20); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.WaitN(ctx, "Op", 20); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.Now().Sub(start); got != 2*time.Second {
+		t.Fatalf("second 20-item request waited %v, want 2s", got)
+	}
+	// A request larger than the burst is charged the whole burst.
+	if err := l.WaitN(ctx, "Op", 50); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.Now().Sub(start); got != 4*time.Second {
+		t.Fatalf("oversized request waited until %v, want 4s", got)
+	}
+}
+
+func TestRateLimiterHourly(t *testing.T) {
+	l, clock := newTestLimiter(Quota{MaxRequests: 20, RestoreRate: 10, Hourly: 3, PerItem: true})
+	start := clock.Now()
+	for i := 0; i < 3; i++ {
+		// 20 items per request must still count once against the hour.
+		if _, ok := l.reserve("Op", 20); !ok {
+			t.Fatalf("request %d within the hourly quota was delayed", i+1)
+		}
+		clock.Advance(2 * time.Second)
+	}
+	wait, ok := l.reserve("Op", 1)
+	if ok || wait != time.Hour-6*time.Second {
+		t.Fatalf("reserve over the hourly quota = %v, %v; want %v, false", wait, ok, time.Hour-6*time.Second)
+	}
+	clock.Advance(wait)
+	if _, ok := l.reserve("Op", 1); !ok {
+		t.Fatalf("hourly quota not reset at %v", clock.Now().Sub(start))
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	clock := newFakeClock()
+	l := NewRateLimiterWithClock(nil, clock)
+	resets := clock.Now().Add(50 * time.Minute)
+	l.Observe("GetMyPriceForSKU", QuotaStatus{Max: 36000, Remaining: 100, ResetsOn: resets})
+
+	if q, ok := l.QuotaStatus("GetMyPriceForASIN"); !ok || q.Remaining != 100 {
+		t.Fatalf("QuotaStatus of the shared ASIN bucket = %+v, %v", q, ok)
+	}
+
+	// Amazon still accepts 100 requests, whatever their item count.
+	ctx := context.Background()
+	start := clock.Now()
+	for i := 0; i < 100; i++ {
+		if err := l.WaitN(ctx, "GetMyPriceForSKU", 20); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.Now().Sub(start); got > 200*time.Second {
+		t.Fatalf("100 observed requests took %v", got)
+	}
+	wait, ok := l.reserve("GetMyPriceForSKU", 1)
+	if ok || !clock.Now().Add(wait).Equal(resets) {
+		t.Fatalf("reserve past Remaining = %v, %v; want a wait until %v", wait, ok, resets)
+	}
+}
+
+func TestLiteralClientRateLimiter(t *testing.T) {
+	a := MWSAPI{SellerID: "LITERAL1"}
+	b := MWSAPI{SellerID: "LITERAL1", MarketplaceID: "ATVPDKIKX0DER"}
+	other := MWSAPI{SellerID: "LITERAL2"}
+	if a.rateLimiter() == nil || a.rateLimiter() != b.rateLimiter() {
+		t.Fatal("literal clients of one seller do not share a RateLimiter")
+	}
+	if a.rateLimiter() == other.rateLimiter() {
+		t.Fatal("literal clients of different sellers share a RateLimiter")
+	}
+
+	api, err := NewMWSAPI(MWSAPI{SellerID: "LITERAL1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.rateLimiter() == a.rateLimiter() {
+		t.Fatal("NewMWSAPI client uses the shared literal RateLimiter")
+	}
+	api, err = NewMWSAPI(MWSAPI{SellerID: "LITERAL1"}, WithRateLimiter(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.rateLimiter() != nil {
+		t.Fatal("WithRateLimiter(nil) did not turn rate limiting off")
+	}
+}
+
+func TestWithRateLimiterClock(t *testing.T) {
+	clock := newFakeClock()
+	l := NewRateLimiterWithClock(map[string]Quota{"Op": {MaxRequests: 1, RestoreRate: 1.0 / 60}}, clock)
+	api, err := NewMWSAPI(MWSAPI{SellerID: "S"}, WithRateLimiter(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := api.rateLimiter().Wait(ctx, "Op"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.Now().Sub(newFakeClock().Now()); got != time.Minute {
+		t.Fatalf("custom limiter waited %v on its Clock, want 1m", got)
+	}
+}
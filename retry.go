@@ -0,0 +1,140 @@
+package amazonmws
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how the request pipeline re-sends requests that
+// failed with a retryable MWS error code or a 5xx status. Every retry is
+// signed again with a fresh Timestamp.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff holds the wait before each retry; the last entry is reused
+	// once the list runs out.
+	Backoff []time.Duration
+	// Jitter randomizes each wait by up to this fraction in either
+	// direction, e.g. 0.1 for ±10%.
+	Jitter float64
+	// OnRetry, if set, is called before waiting to retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	Operation string
+	Attempt   int /*the attempt that failed, from 1*/
+	Err       error
+	Wait      time.Duration
+}
+
+// DefaultRetryPolicy follows Amazon's recommendation of four retries
+// spaced 1s, 4s, 10s and 30s apart.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     []time.Duration{time.Second, 4 * time.Second, 10 * time.Second, 30 * time.Second},
+	Jitter:      0.1,
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for the client.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(api *MWSAPI) error {
+		api.retryPolicy = &p
+		return nil
+	}
+}
+
+// RetryMiddleware retries Calls as p allows. The client installs one
+// built from its RetryPolicy; set MaxAttempts to 1 with WithRetryPolicy
+// before adding another through WithMiddleware.
+func RetryMiddleware(p RetryPolicy) Middleware {
+	return retryMiddleware(p, systemClock{}, nil, nil)
+}
+
+func retryMiddleware(p RetryPolicy, clock Clock, skew *clockSkew, l Logger) Middleware {
+	logger := loggerOrNop(l)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			for attempt := 1; ; attempt++ {
+				call.Attempt = attempt
+				reply, err := next(ctx, call)
+				if err == nil {
+					return reply, nil
+				}
+				if attempt >= p.MaxAttempts {
+					logger.Log(ctx, slog.LevelError, "mws operation failed", errorFields(call, err)...)
+					return reply, err
+				}
+				var wait time.Duration
+				switch {
+				case skew != nil && skew.correct(err, clock.Now()):
+					// Retry at once with the corrected Timestamp.
+				case Retryable(err):
+					wait = p.wait(attempt)
+				default:
+					logger.Log(ctx, slog.LevelError, "mws operation failed", errorFields(call, err)...)
+					return reply, err
+				}
+				logger.Log(ctx, slog.LevelWarn, "mws retry", append(errorFields(call, err), "wait", wait)...)
+				if p.OnRetry != nil {
+					p.OnRetry(RetryEvent{Operation: call.Operation, Attempt: attempt, Err: err, Wait: wait})
+				}
+				if err := sleepContext(ctx, clock, wait); err != nil {
+					return reply, err
+				}
+			}
+		}
+	}
+}
+
+// errorFields describes a failed attempt for the Logger, including the
+// MWS error code and RequestId when err is an *APIError.
+func errorFields(call *Call, err error) []any {
+	fields := []any{
+		"operation", call.Operation,
+		"seller", call.SellerID,
+		"marketplace", call.Params["MarketplaceId"],
+		"attempt", call.Attempt,
+		"error", err,
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		fields = append(fields, "status", apiErr.StatusCode, "code", apiErr.Code, "request_id", apiErr.RequestID)
+	}
+	return fields
+}
+
+func (p RetryPolicy) wait(attempt int) time.Duration {
+	if len(p.Backoff) == 0 {
+		return 0
+	}
+	i := attempt - 1
+	if i >= len(p.Backoff) {
+		i = len(p.Backoff) - 1
+	}
+	d := p.Backoff[i]
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// Retryable reports whether err is an *APIError worth sending again:
+// one of the throttling codes CheckCode treats as valid, or a 5xx status.
+func Retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, c := range retryableCodes {
+		if apiErr.Code == c {
+			return true
+		}
+	}
+	return apiErr.StatusCode >= 500
+}
@@ -0,0 +1,85 @@
+package amazonmws
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryThrottled(t *testing.T) {
+	var timestamps []string
+	api, clock := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		timestamps = append(timestamps, r.URL.Query().Get("Timestamp"))
+		if len(timestamps) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(throttledXML))
+			return
+		}
+		w.Write([]byte(`<GetMyPriceForSKUResponse/>`))
+	})
+	var events []RetryEvent
+	api.retryPolicy = &RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     []time.Duration{time.Second, 4 * time.Second},
+		OnRetry:     func(e RetryEvent) { events = append(events, e) },
+	}
+
+	start := clock.Now()
+	if _, err := api.GetMyPriceForSKU([]string{"sku"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(timestamps) != 3 {
+		t.Fatalf("%d attempts, want 3", len(timestamps))
+	}
+	if timestamps[0] == timestamps[1] || timestamps[1] == timestamps[2] {
+		t.Fatalf("attempts reused a Timestamp: %q", timestamps)
+	}
+	if got := clock.Now().Sub(start); got != 5*time.Second {
+		t.Fatalf("waited %v between attempts, want 1s+4s", got)
+	}
+	if len(events) != 2 || events[0].Attempt != 1 || events[0].Wait != time.Second ||
+		events[1].Attempt != 2 || events[1].Wait != 4*time.Second || events[1].Operation != "GetMyPriceForSKU" {
+		t.Fatalf("OnRetry events = %+v", events)
+	}
+	var apiErr *APIError
+	if !errors.As(events[0].Err, &apiErr) || apiErr.Code != ReqThrottled {
+		t.Fatalf("OnRetry error = %v", events[0].Err)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	attempts := 0
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(throttledXML))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: []time.Duration{time.Second}}))
+
+	_, err := api.GetMyPriceForSKU([]string{"sku"})
+	if attempts != 3 {
+		t.Fatalf("%d attempts, want 3", attempts)
+	}
+	if !Retryable(err) {
+		t.Fatalf("err = %v, want the last throttling error", err)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	attempts := 0
+	retried := false
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>SignatureDoesNotMatch</Code><Message>no</Message></Error><RequestID>r</RequestID></ErrorResponse>`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 5, OnRetry: func(RetryEvent) { retried = true }}))
+
+	_, err := api.GetMyPriceForSKU([]string{"sku"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != SignDoesNotMatch {
+		t.Fatalf("err = %v", err)
+	}
+	if attempts != 1 || retried {
+		t.Fatalf("%d attempts, OnRetry called %v; want 1, false", attempts, retried)
+	}
+}
@@ -0,0 +1,88 @@
+package amazonmws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SellerPool holds a client per seller for developers acting on behalf of
+// other sellers through MWSAuthToken. MWS throttles each seller
+// separately, so every seller gets its own RateLimiter.
+// It is safe for concurrent use.
+type SellerPool struct {
+	mu        sync.RWMutex
+	developer MWSAPI
+	opts      []Option
+	sellers   map[string]*MWSAPI
+}
+
+// NewSellerPool returns an empty pool. developer supplies the AccessKey and
+// SecretKey shared by all sellers; opts are applied to every seller's client
+// and should not include a shared WithRateLimiter. A WithCredentialsProvider
+// among opts only supplies the keys: each client keeps the SellerID and
+// AuthToken given to Add.
+func NewSellerPool(developer MWSAPI, opts ...Option) *SellerPool {
+	return &SellerPool{developer: developer, opts: opts, sellers: make(map[string]*MWSAPI)}
+}
+
+// Add registers sellerID with its authToken and home marketplace,
+// a country code or MarketplaceId, replacing any earlier registration.
+func (p *SellerPool) Add(sellerID, authToken, marketplace string) error {
+	m, ok := resolveMarketplace(marketplace)
+	if !ok {
+		return fmt.Errorf("amazonmws: unknown marketplace %q", marketplace)
+	}
+	config := p.developer
+	config.SellerID = sellerID
+	config.AuthToken = authToken
+	api, err := NewMWSAPIForMarketplace(m.CountryCode, config, p.opts...)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sellers[sellerID] = api
+	return nil
+}
+
+// Remove forgets sellerID.
+func (p *SellerPool) Remove(sellerID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sellers, sellerID)
+}
+
+// Seller returns the client for sellerID.
+func (p *SellerPool) Seller(sellerID string) (*MWSAPI, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	api, ok := p.sellers[sellerID]
+	if !ok {
+		return nil, fmt.Errorf("amazonmws: seller %q is not in the pool", sellerID)
+	}
+	return api, nil
+}
+
+// Sellers lists the registered SellerIDs in sorted order.
+func (p *SellerPool) Sellers() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ids := make([]string, 0, len(p.sellers))
+	for id := range p.sellers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Do routes fn to the client for sellerID.
+func (p *SellerPool) Do(ctx context.Context, sellerID string, fn func(ctx context.Context, api MWSAPI) error) error {
+	api, err := p.Seller(sellerID)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, *api)
+}
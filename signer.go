@@ -0,0 +1,77 @@
+package amazonmws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Signer computes MWS Signature Version 2 signatures with HmacSHA256.
+//
+// The parameters are canonicalized as the specification requires: sorted by
+// key in byte order, with keys and values percent-encoded per RFC 3986.
+// Only the unreserved characters A-Z, a-z, 0-9, '-', '_', '.' and '~' are
+// left alone; every other byte of the UTF-8 encoding becomes %XX in upper
+// case, so a space is %20 (never '+') and '*' is %2A.
+//
+// ExampleSigner and ExampleEscape hold the test vectors.
+type Signer struct {
+	SecretKey string
+}
+
+// Escape percent-encodes s as RFC 3986 and Signature Version 2 require.
+func Escape(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&15])
+	}
+	return b.String()
+}
+
+// CanonicalQuery returns values as the canonical query string: escaped
+// key=value pairs sorted by key in byte order and joined by '&'.
+func CanonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			pairs = append(pairs, Escape(k)+"="+Escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// StringToSign returns the string signed for a request with the given
+// HTTP method, host, path and parameters (without Signature).
+func (s Signer) StringToSign(method, host, path string, values url.Values) string {
+	if path == "" {
+		path = "/"
+	}
+	return method + "\n" + strings.ToLower(host) + "\n" + path + "\n" + CanonicalQuery(values)
+}
+
+// Sign returns the base64 HmacSHA256 signature of the request.
+func (s Signer) Sign(method, host, path string, values url.Values) string {
+	hasher := hmac.New(sha256.New, []byte(s.SecretKey))
+	hasher.Write([]byte(s.StringToSign(method, host, path, values)))
+	return base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+}
@@ -1,7 +1,8 @@
 package amazonmws
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"math"
 	"sync"
 	"time"
@@ -24,8 +25,10 @@ type Throttle struct {
 	SleepMap  map[int]int64
 	Start     time.Time
 	Synced    *sync.Mutex
-	Timer     *time.Timer
-	Ticker    *time.Ticker
+	Timer     *time.Timer  /*unused, waits are timed by Clock*/
+	Ticker    *time.Ticker /*unused, waits are timed by Clock*/
+	Clock     Clock        /*nil for the system clock*/
+	Logger    Logger       /*nil to discard*/
 }
 
 // Request are the throttling rates for the Products API section operations that only throttle per request.
@@ -52,54 +55,45 @@ func NewRequest() *Request {
 
 // NewThrottler creates a new Throttler
 func NewThrottler() *Throttle {
-	return &Throttle{"", time.Second, false, false, 0, 0, 0, nil, time.Now(), &sync.Mutex{}, time.NewTimer(1 * time.Nanosecond), time.NewTicker(1 * time.Nanosecond)}
+	return NewThrottlerWithClock(nil)
 }
 
-// Limiter aids in preventing excess throttling
+// NewThrottlerWithClock is NewThrottler with Start and every wait taken
+// from clock, e.g. a fake one in tests; nil is the system clock.
+func NewThrottlerWithClock(clock Clock) *Throttle {
+	return &Throttle{"", time.Second, false, false, 0, 0, 0, nil, clockOrSystem(clock).Now(), &sync.Mutex{}, nil, nil, clock, nil}
+}
+
+// Limiter aids in preventing excess throttling.
+// in is sent on out in chunks of MaxBatchSize[t.Operation], or 5 when the
+// operation has no entry, waiting on the ticker between chunks; the last
+// chunk may be shorter. out is closed after the last chunk. A single chunk
+// is sent before Limiter returns, more are sent from a goroutine.
 func (t *Throttle) Limiter(in []string, out chan []string) {
-	x := len(in)
-	// pass := make(chan []string)
 	y := 5
-	// defer close(out)
-	if x > 0 {
-		s := splitList(x, y)
-		// m := modList(float64(x), float64(y))
-		if x > y {
-			go func() {
-				for i := 0; i < s; i++ {
-					// for {
-					l := len(in)
-					if l > y {
-						t.NewTicker()
-						fmt.Printf("sending %v strings %v\n", y, in[0:y])
-						out <- in[0:y]
-						// fmt.Println("waiting")
-					} else {
-						// t.Sleeper()
-						// t.NewTicker()
-						fmt.Printf("sending %v strings\n%v\n", l, in[0:l])
-						out <- in[0:l]
-						// fmt.Println("waiting")
-					}
-					if l > y {
-						in = append(in[:0], in[y:]...)
-					} else {
-						in = append(in[:0], in[l:]...)
-					}
-					// if l == 0 {
-					// 	return
-					// }
-				}
-				close(out)
-			}()
-		} else {
-			// t.NewTicker()
-			fmt.Printf("sending %v strings\n", x)
-			out <- in
-			close(out)
-		}
-
+	if n, ok := MaxBatchSize[t.Operation]; ok {
+		y = n
+	}
+	chunks := chunk(in, y)
+	switch len(chunks) {
+	case 0:
+		return
+	case 1:
+		t.log(context.Background(), slog.LevelDebug, "sending strings", "count", len(in))
+		out <- chunks[0]
+		close(out)
+		return
 	}
+	go func() {
+		for i, c := range chunks {
+			if i > 0 {
+				t.NewTicker()
+			}
+			t.log(context.Background(), slog.LevelDebug, "sending strings", "count", len(c), "strings", c)
+			out <- c
+		}
+		close(out)
+	}()
 }
 
 // Sleepy stores throttling durations
@@ -115,62 +109,72 @@ func (t *Throttle) Sleepy() {
 
 // Sleeper handles throttling durations
 func (t *Throttle) Sleeper() {
+	t.SleeperContext(context.Background())
+}
+
+// SleeperContext is Sleeper but returns ctx.Err() as soon as ctx is done
+// instead of finishing the sleep.
+func (t *Throttle) SleeperContext(ctx context.Context) error {
 	if len(t.SleepMap) == 0 {
 		t.Sleepy()
 	}
 	v, ok := t.SleepMap[t.Attempt]
 	if ok == false {
 		t.Sleepy()
-		t.Sleeper()
+		return t.SleeperContext(ctx)
 	}
 	t.Duration = time.Duration(v) * time.Second
-	t.Throttler()
+	return t.ThrottlerContext(ctx)
 	// t.NewTicker()
 }
 
-// NewTimer initializes a time.NewTimer
+// NewTimer waits for d on a timer from t.Clock
 func (t *Throttle) NewTimer(d time.Duration) {
-	if t.Timer.Stop() {
-		t.Timer.Reset(d)
-	}
-	<-t.Timer.C
+	timer := clockOrSystem(t.Clock).NewTimer(d)
+	<-timer.C()
 }
 
 // NewTicker initializes a time.NewTicker
 func (t *Throttle) NewTicker() {
-	t.Ticker = time.NewTicker(time.Second)
-	defer t.Ticker.Stop()
-	done := make(chan bool, 1)
+	t.NewTickerContext(context.Background())
+}
+
+// NewTickerContext is NewTicker but stops ticking and returns ctx.Err()
+// as soon as ctx is done.
+func (t *Throttle) NewTickerContext(ctx context.Context) error {
+	ticker := clockOrSystem(t.Clock).NewTicker(time.Second)
+	defer ticker.Stop()
+	done := make(chan error, 1)
 	go func() {
-		t.Sleeper()
+		done <- t.SleeperContext(ctx)
 		// time.Sleep(time.Second)
-		done <- true
 		// for nt := range t.Ticker.C {
 		// 	fmt.Printf("ticking for %v at %v\n", time.Second, nt)
 		// }
 	}()
 	for {
 		select {
-		case <-done:
-			fmt.Println("Finished ticking")
-			return
-		case t := <-t.Ticker.C:
-			fmt.Println("sleeper is ticking", t)
+		case err := <-done:
+			t.log(ctx, slog.LevelDebug, "finished ticking")
+			return err
+		case tick := <-ticker.C():
+			t.log(ctx, slog.LevelDebug, "sleeper is ticking", "time", tick)
 		}
 	}
 	// <-t.Ticker.C
 
 }
 
-// NewSlowTicker initializes a time.NewTicker
+// NewSlowTicker waits on t.Clock until an hour after t.Start
 func (t *Throttle) NewSlowTicker() {
-	t.Ticker = time.NewTicker(time.Minute)
-	defer t.Ticker.Stop()
+	clock := clockOrSystem(t.Clock)
+	ticker := clock.NewTicker(time.Minute)
+	defer ticker.Stop()
 	done := make(chan bool, 1)
 	go func() {
 		wait := t.Start.Add(time.Hour)
 		// time.Sleep(wait.Sub(time.Now()))
-		t.NewTimer(wait.Sub(time.Now()))
+		t.NewTimer(wait.Sub(clock.Now()))
 		done <- true
 		// for nt := range t.Ticker.C {
 		// 	fmt.Printf("ticking for %v at %v\n", time.Second, nt)
@@ -179,10 +183,10 @@ func (t *Throttle) NewSlowTicker() {
 	for {
 		select {
 		case <-done:
-			fmt.Println("slow sleeper finished ticking")
+			t.log(context.Background(), slog.LevelDebug, "slow sleeper finished ticking")
 			return
-		case t := <-t.Ticker.C:
-			fmt.Println("slow sleeper is ticking", t)
+		case tick := <-ticker.C():
+			t.log(context.Background(), slog.LevelDebug, "slow sleeper is ticking", "time", tick)
 		}
 	}
 	// <-t.Ticker.C
@@ -191,19 +195,41 @@ func (t *Throttle) NewSlowTicker() {
 
 // Throttler sleeps
 func (t *Throttle) Throttler() {
+	t.ThrottlerContext(context.Background())
+}
+
+// ThrottlerContext sleeps like Throttler but wakes early and returns
+// ctx.Err() when ctx is done. The attempt counter only advances on a
+// completed sleep.
+func (t *Throttle) ThrottlerContext(ctx context.Context) error {
+	t.log(ctx, slog.LevelDebug, "sleeping", "duration", t.Duration)
+	if err := sleepContext(ctx, t.Clock, t.Duration); err != nil {
+		return err
+	}
 	if t.Attempt == 3 {
-		fmt.Println("sleeping for :", t.Duration)
-		time.Sleep(t.Duration)
 		t.Attempt = 0
 	} else {
-		fmt.Println("sleeping for :", t.Duration)
-		time.Sleep(t.Duration)
 		t.Attempt++
 	}
+	return nil
+}
+
+// sleepContext pauses for d on clock or until ctx is done, whichever
+// comes first. A nil clock is the system clock.
+func sleepContext(ctx context.Context, clock Clock, d time.Duration) error {
+	timer := clockOrSystem(clock).NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
 }
-func splitList(x, y int) int {
-	return x / y
+func (t *Throttle) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	loggerOrNop(t.Logger).Log(ctx, level, msg, args...)
 }
+
 func modList(x, y float64) float64 {
 	return math.Mod(x, y)
 }
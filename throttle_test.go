@@ -0,0 +1,61 @@
+package amazonmws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestThrottleLimiterChunks(t *testing.T) {
+	in := make([]string, 45)
+	for i := range in {
+		in[i] = fmt.Sprintf("ASIN%02d", i)
+	}
+	want := append([]string(nil), in...)
+
+	th := NewThrottler()
+	th.Operation = "GetLowestOfferListingsForASIN"
+	th.Clock = newFakeClock()
+	out := make(chan []string)
+	th.Limiter(in, out)
+
+	var sizes []int
+	var got []string
+	for c := range out {
+		sizes = append(sizes, len(c))
+		got = append(got, c...)
+	}
+	if !reflect.DeepEqual(sizes, []int{20, 20, 5}) {
+		t.Fatalf("chunk sizes = %v, want [20 20 5]", sizes)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("items received = %v, want %v", got, want)
+	}
+}
+
+func TestThrottleLimiterSingleChunk(t *testing.T) {
+	th := NewThrottler()
+	out := make(chan []string, 1)
+	th.Limiter([]string{"a", "b"}, out)
+	if c := <-out; !reflect.DeepEqual(c, []string{"a", "b"}) {
+		t.Fatalf("chunk = %v", c)
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("out not closed after the only chunk")
+	}
+}
+
+func TestThrottleSlowTickerUsesClock(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	th := NewThrottlerWithClock(clock)
+	if !th.Start.Equal(start) {
+		t.Fatalf("Start = %v, want the clock's %v", th.Start, start)
+	}
+	clock.Advance(10 * time.Minute)
+	th.NewSlowTicker()
+	if got := clock.Now().Sub(start); got != time.Hour {
+		t.Fatalf("slow ticker returned %v after Start, want 1h", got)
+	}
+}
@@ -1,16 +1,12 @@
 package amazonmws
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
-	"fmt"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
-	"sort"
 	"strings"
 	"time"
 )
@@ -23,81 +19,159 @@ type MWSAPI struct {
 	AuthToken     string
 	MarketplaceID string
 	SellerID      string
+
+	client    *http.Client
+	scheme    string
+	userAgent string
+	clock     Clock
+	skew      *clockSkew
+
+	credentials CredentialsProvider
+	postOps     map[string]bool
+	retryPolicy *RetryPolicy
+	limiter     *RateLimiter
+	unlimited   bool /*WithRateLimiter(nil)*/
+
+	quotaObserver func(operation string, q QuotaStatus)
+	logger        Logger
+	middleware    []Middleware
+	metrics       *Metrics
 }
 
-func (api MWSAPI) genSignAndFetch(Action string, ActionPath string, Parameters map[string]string) (string, error) {
-	genURL, err := GenerateAmazonURL(api, Action, ActionPath, Parameters)
-	if err != nil {
-		return "", err
+func (api MWSAPI) genSignAndFetch(ctx context.Context, Action string, ActionPath string, Parameters map[string]string) (string, error) {
+	body, err := api.fetch(ctx, Action, ActionPath, Parameters)
+	return string(body), err
+}
+
+// fetch passes the request through the client's middleware chain and
+// returns the body. A non-2xx status or an ErrorResponse envelope is
+// returned as an *APIError along with the body, so it can still be inspected.
+func (api MWSAPI) fetch(ctx context.Context, Action string, ActionPath string, Parameters map[string]string) ([]byte, error) {
+	reply, err := api.handler()(ctx, &Call{
+		Operation: Action,
+		Path:      ActionPath,
+		Params:    Parameters,
+		SellerID:  api.SellerID,
+		Header:    http.Header{},
+	})
+	if reply == nil {
+		return nil, err
 	}
+	return reply.Body, err
+}
 
-	SetTimestamp(genURL)
+// genSignAndGet is fetch streaming a successful body to the file dst,
+// so large reports are never held in memory. dst is not touched when
+// the request fails.
+func (api MWSAPI) genSignAndGet(ctx context.Context, Action string, ActionPath string, Parameters map[string]string, dst string) error {
+	_, err := api.handler()(ctx, &Call{
+		Operation: Action,
+		Path:      ActionPath,
+		Params:    Parameters,
+		SellerID:  api.SellerID,
+		Header:    http.Header{},
+		Stream: func() (io.WriteCloser, error) {
+			return os.OpenFile(dst, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+		},
+	})
+	return err
+}
 
-	signedurl, err := SignAmazonURL(genURL, api)
-	if err != nil {
-		return "", err
+// send is the Handler at the end of every chain: it generates and signs
+// the URL for call, sends it and reads the reply, giving up as soon as ctx
+// is done.
+func (api MWSAPI) send(ctx context.Context, call *Call) (*Reply, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	resp, err := http.Get(signedurl)
-	if err != nil {
-		return "", err
+	if api.credentials != nil {
+		c, err := api.credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = api.apply(c)
 	}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	genURL, err := GenerateAmazonURL(api, call.Operation, call.Path, call.Params)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(body), nil
-}
-func (api MWSAPI) genSignAndGet(Action string, ActionPath string, Parameters map[string]string, dst string) error {
-	genURL, err := GenerateAmazonURL(api, Action, ActionPath, Parameters)
+	setTimestamp(genURL, api.now())
+
+	method := api.method(call.Operation)
+	signedurl, err := signAmazonURL(method, genURL, api)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	SetTimestamp(genURL)
+	var req *http.Request
+	if method == http.MethodPost {
+		// The signed parameters travel in the body instead of the query.
+		body := genURL.RawQuery
+		genURL.RawQuery = ""
+		req, err = http.NewRequestWithContext(ctx, method, genURL.String(), strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, signedurl, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range call.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", api.userAgentHeader())
 
-	signedurl, err := SignAmazonURL(genURL, api)
+	resp, err := api.httpClient().Do(req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if api.quotaObserver != nil {
+		if q, ok := parseQuotaHeaders(resp.Header); ok {
+			api.quotaObserver(call.Operation, q)
+		}
 	}
 
-	resp, err := http.Get(signedurl)
+	reply := &Reply{StatusCode: resp.StatusCode, Header: resp.Header}
+	if call.Stream != nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return reply, stream(call.Stream, resp.Body)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	reply.Body = body
+	return reply, checkResponse(resp, body)
+}
 
-	out, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE, 0755)
+// stream copies body into the writer open returns and closes it.
+func stream(open func() (io.WriteCloser, error), body io.Reader) error {
+	out, err := open()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		resp.Body.Close()
+	if _, err := io.Copy(out, body); err != nil {
 		out.Close()
-	}()
-	// defer resp.Body.Close()
-	// body, err := ioutil.ReadAll(resp.Body)
-	if _, err := io.Copy(out, resp.Body); err != nil {
 		return err
 	}
-	// if err != nil {
-	// 	return "", err
-	// }
-
-	return nil
+	return out.Close()
 }
 
 // GenerateAmazonURL prepares the url in genSignAndFetch
 func GenerateAmazonURL(api MWSAPI, Action string, ActionPath string, Parameters map[string]string) (finalURL *url.URL, err error) {
-	result, err := url.Parse(api.Host)
-	if err != nil {
-		return nil, err
-	}
-
+	result := &url.URL{}
 	result.Host = api.Host
 	result.Scheme = "https"
+	if api.scheme != "" {
+		result.Scheme = api.scheme
+	}
 	result.Path = ActionPath
 
 	values := url.Values{}
@@ -128,11 +202,15 @@ func GenerateAmazonURL(api MWSAPI, Action string, ActionPath string, Parameters
 
 // SetTimestamp adds a RFC3339 timestamp to the URL
 func SetTimestamp(origURL *url.URL) (err error) {
+	return setTimestamp(origURL, time.Now())
+}
+
+func setTimestamp(origURL *url.URL, now time.Time) error {
 	values, err := url.ParseQuery(origURL.RawQuery)
 	if err != nil {
 		return err
 	}
-	values.Set("Timestamp", time.Now().UTC().Format(time.RFC3339))
+	values.Set("Timestamp", now.UTC().Format(time.RFC3339))
 	origURL.RawQuery = values.Encode()
 
 	return nil
@@ -140,28 +218,26 @@ func SetTimestamp(origURL *url.URL) (err error) {
 
 // SignAmazonURL encodes the SecretKey signing the URL
 func SignAmazonURL(origURL *url.URL, api MWSAPI) (signedURL string, err error) {
-	escapeURL := strings.Replace(origURL.RawQuery, ",", "%2C", -1)
-	escapeURL = strings.Replace(escapeURL, ":", "%3A", -1)
-
-	params := strings.Split(escapeURL, "&")
-	sort.Strings(params)
-	sortedParams := strings.Join(params, "&")
+	return signAmazonURL(http.MethodGet, origURL, api)
+}
 
-	toSign := fmt.Sprintf("GET\n%s\n%s\n%s", origURL.Host, origURL.Path, sortedParams)
+// SignAmazonPOST signs origURL like SignAmazonURL for the POST form of
+// Signature Version 2. The signed query of the returned URL is the
+// application/x-www-form-urlencoded body to send.
+func SignAmazonPOST(origURL *url.URL, api MWSAPI) (signedURL string, err error) {
+	return signAmazonURL(http.MethodPost, origURL, api)
+}
 
-	hasher := hmac.New(sha256.New, []byte(api.SecretKey))
-	_, err = hasher.Write([]byte(toSign))
+func signAmazonURL(method string, origURL *url.URL, api MWSAPI) (signedURL string, err error) {
+	values, err := url.ParseQuery(origURL.RawQuery)
 	if err != nil {
 		return "", err
 	}
+	values.Del("Signature")
 
-	hash := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
-
-	hash = url.QueryEscape(hash)
-
-	newParams := fmt.Sprintf("%s&Signature=%s", sortedParams, hash)
+	signature := Signer{SecretKey: api.SecretKey}.Sign(method, origURL.Host, origURL.Path, values)
 
-	origURL.RawQuery = newParams
+	origURL.RawQuery = CanonicalQuery(values) + "&Signature=" + Escape(signature)
 
 	return origURL.String(), nil
 }
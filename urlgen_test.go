@@ -0,0 +1,130 @@
+package amazonmws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestClient returns a client sending to a local server running h,
+// with a fakeClock so retries do not sleep.
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) (*MWSAPI, *fakeClock) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	clock := newFakeClock()
+	config := MWSAPI{AccessKey: "AK", SecretKey: "secret", SellerID: "SELLER", MarketplaceID: "ATVPDKIKX0DER"}
+	api, err := NewMWSAPI(config, append([]Option{WithBaseURL(srv.URL), WithClock(clock)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api, clock
+}
+
+const throttledXML = `<ErrorResponse><Error><Type>Sender</Type><Code>RequestThrottled</Code><Message>Request is throttled</Message></Error><RequestID>req-1</RequestID></ErrorResponse>`
+
+func TestGenSignAndGetStreams(t *testing.T) {
+	report := strings.Repeat("sku\tprice\n", 1000)
+	attempts := 0
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(throttledXML))
+			return
+		}
+		w.Write([]byte(report))
+	})
+
+	dst := filepath.Join(t.TempDir(), "report.txt")
+	// A longer file left from an earlier download must not leave a tail.
+	if err := os.WriteFile(dst, []byte(report+"stale bytes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.genSignAndGet(context.Background(), "GetReport", reportAPI, map[string]string{"ReportId": "1"}, dst); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 || string(got) != report {
+		t.Fatalf("after %d attempts the file holds %d bytes, want %d", attempts, len(got), len(report))
+	}
+}
+
+func TestGenSignAndGetFailureLeavesFile(t *testing.T) {
+	api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>InvalidReportId</Code><Message>bad</Message></Error><RequestID>r</RequestID></ErrorResponse>`))
+	})
+	dst := filepath.Join(t.TempDir(), "report.txt")
+	err := api.genSignAndGet(context.Background(), "GetReport", reportAPI, nil, dst)
+	if err == nil {
+		t.Fatal("want an error")
+	}
+	if _, statErr := os.Stat(dst); !os.IsNotExist(statErr) {
+		t.Fatalf("failed download created %s", dst)
+	}
+}
+
+// verifySignature is a server-side check of the Signature Version 2
+// signature on r, as MWS would do it.
+func verifySignature(t *testing.T, r *http.Request, secret string) url.Values {
+	t.Helper()
+	if err := r.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+	values := r.Form
+	if r.Method == http.MethodPost {
+		values = r.PostForm
+	}
+	got := values.Get("Signature")
+	values.Del("Signature")
+	if want := (Signer{SecretKey: secret}).Sign(r.Method, r.Host, r.URL.Path, values); got != want {
+		t.Errorf("%s Signature = %q, want %q", r.Method, got, want)
+	}
+	return values
+}
+
+func TestSendSignsGetAndPost(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		t.Run(method, func(t *testing.T) {
+			var opts []Option
+			if method == http.MethodPost {
+				opts = append(opts, WithPOST("GetMyPriceForSKU"))
+			}
+			called := false
+			api, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.Method != method {
+					t.Errorf("method = %s, want %s", r.Method, method)
+				}
+				if method == http.MethodPost {
+					if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/x-www-form-urlencoded") {
+						t.Errorf("Content-Type = %q", ct)
+					}
+					if r.URL.RawQuery != "" {
+						t.Errorf("POST query = %q, want it empty", r.URL.RawQuery)
+					}
+				}
+				values := verifySignature(t, r, "secret")
+				if values.Get("SellerSKUList.SellerSKU.1") != "sku" || values.Get("Timestamp") == "" {
+					t.Errorf("params = %v", values)
+				}
+				w.Write([]byte(`<GetMyPriceForSKUResponse/>`))
+			}, opts...)
+			if _, err := api.GetMyPriceForSKU([]string{"sku"}); err != nil {
+				t.Fatal(err)
+			}
+			if !called {
+				t.Fatal("server not called")
+			}
+		})
+	}
+}